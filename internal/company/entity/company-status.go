@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/events"
+)
+
+type CompanyStatus string
+
+const (
+	StatusActive           CompanyStatus = "active"
+	StatusSuspended        CompanyStatus = "suspended"
+	StatusUnderLiquidation CompanyStatus = "under_liquidation"
+	StatusClosed           CompanyStatus = "closed"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("company status transition not allowed")
+	ErrTransitionReason        = errors.New("company status transition requires a reason")
+	ErrTransitionBeforeLatest  = errors.New("company status transition must not happen before the latest one")
+	ErrActiveConstructions     = errors.New("company has active constructions")
+	ErrActivePartners          = errors.New("company has active partners")
+)
+
+var allowedStatusTransitions = map[CompanyStatus][]CompanyStatus{
+	StatusActive:           {StatusSuspended, StatusUnderLiquidation, StatusClosed},
+	StatusSuspended:        {StatusActive, StatusUnderLiquidation, StatusClosed},
+	StatusUnderLiquidation: {StatusActive, StatusClosed},
+	StatusClosed:           {},
+}
+
+// CompanyDependencies describes what still depends on the company when a transition is requested.
+// It is filled by the caller from the construction and partner modules.
+type CompanyDependencies struct {
+	ActiveConstructions int
+	ActivePartners      int
+}
+
+type statusGuard func(c Company, deps CompanyDependencies) error
+
+var statusGuards = map[CompanyStatus][]statusGuard{
+	StatusClosed: {noActiveConstructions, noActivePartners},
+}
+
+func noActiveConstructions(c Company, deps CompanyDependencies) error {
+	if deps.ActiveConstructions > 0 {
+		return fmt.Errorf("%w: %d", ErrActiveConstructions, deps.ActiveConstructions)
+	}
+	return nil
+}
+
+func noActivePartners(c Company, deps CompanyDependencies) error {
+	if deps.ActivePartners > 0 {
+		return fmt.Errorf("%w: %d", ErrActivePartners, deps.ActivePartners)
+	}
+	return nil
+}
+
+type StatusTransition struct {
+	From   CompanyStatus `validate:"required"`
+	To     CompanyStatus `validate:"required,oneof=active suspended under_liquidation closed"`
+	Reason string        `validate:"required,min=3"`
+	At     time.Time     `validate:"required"`
+}
+
+type CompanyStatusChanged struct {
+	CompanyID string
+	From      CompanyStatus
+	To        CompanyStatus
+	Reason    string
+	At        time.Time
+}
+
+func (e CompanyStatusChanged) Name() string {
+	return "company." + string(e.To)
+}
+
+func (e CompanyStatusChanged) OccurredAt() time.Time {
+	return e.At
+}
+
+func (c *Company) Suspend(reason string, at time.Time) error {
+	return c.TransitionTo(StatusSuspended, reason, at, CompanyDependencies{})
+}
+
+func (c *Company) Reactivate(reason string, at time.Time) error {
+	return c.TransitionTo(StatusActive, reason, at, CompanyDependencies{})
+}
+
+func (c *Company) StartLiquidation(reason string, at time.Time) error {
+	return c.TransitionTo(StatusUnderLiquidation, reason, at, CompanyDependencies{})
+}
+
+func (c *Company) Close(reason string, at time.Time, deps CompanyDependencies) error {
+	return c.TransitionTo(StatusClosed, reason, at, deps)
+}
+
+func (c *Company) TransitionTo(to CompanyStatus, reason string, at time.Time, deps CompanyDependencies) error {
+	if !c.CanTransitionTo(to) {
+		return fmt.Errorf("%w: from %s to %s", ErrInvalidStatusTransition, c.Status, to)
+	}
+
+	if reason == "" {
+		return ErrTransitionReason
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if at.Before(c.lastStatusChange()) {
+		return ErrTransitionBeforeLatest
+	}
+
+	for _, guard := range statusGuards[to] {
+		if err := guard(*c, deps); err != nil {
+			return err
+		}
+	}
+
+	transition := StatusTransition{From: c.Status, To: to, Reason: reason, At: at}
+
+	updated := c.clone()
+	updated.Status = to
+	updated.StatusHistory = append(updated.StatusHistory, transition)
+
+	if err := validateCompany(updated); err != nil {
+		return err
+	}
+
+	*c = updated
+	c.events = append(c.events, CompanyStatusChanged{
+		CompanyID: c.ID,
+		From:      transition.From,
+		To:        transition.To,
+		Reason:    transition.Reason,
+		At:        transition.At,
+	})
+
+	return nil
+}
+
+func (c Company) CanTransitionTo(to CompanyStatus) bool {
+	for _, allowed := range allowedStatusTransitions[c.Status] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Company) lastStatusChange() time.Time {
+	if n := len(c.StatusHistory); n > 0 {
+		return c.StatusHistory[n-1].At
+	}
+	return c.CreatedAt
+}
+
+// PullEvents returns the events raised since the last call and clears them.
+func (c *Company) PullEvents() []events.Event {
+	pending := c.events
+	c.events = nil
+	return pending
+}
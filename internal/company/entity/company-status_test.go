@@ -0,0 +1,103 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/company/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompany_TransitionTo(t *testing.T) {
+	type testCase struct {
+		test           string
+		from           []entity.CompanyStatus
+		to             entity.CompanyStatus
+		reason         string
+		deps           entity.CompanyDependencies
+		expectedStatus entity.CompanyStatus
+		expectedError  error
+	}
+
+	testsTable := []testCase{
+		{
+			test:           "Suspend an active company",
+			to:             entity.StatusSuspended,
+			reason:         "Pending tax obligations",
+			expectedStatus: entity.StatusSuspended,
+		},
+		{
+			test:           "Transition without reason",
+			to:             entity.StatusSuspended,
+			expectedStatus: entity.StatusActive,
+			expectedError:  entity.ErrTransitionReason,
+		},
+		{
+			test:           "Close a company with active constructions and partners",
+			from:           []entity.CompanyStatus{entity.StatusUnderLiquidation},
+			to:             entity.StatusClosed,
+			reason:         "Distrato registered",
+			deps:           entity.CompanyDependencies{ActiveConstructions: 1, ActivePartners: 2},
+			expectedStatus: entity.StatusUnderLiquidation,
+			expectedError:  entity.ErrActiveConstructions,
+		},
+		{
+			test:           "Close a company with active partners",
+			from:           []entity.CompanyStatus{entity.StatusUnderLiquidation},
+			to:             entity.StatusClosed,
+			reason:         "Distrato registered",
+			deps:           entity.CompanyDependencies{ActivePartners: 2},
+			expectedStatus: entity.StatusUnderLiquidation,
+			expectedError:  entity.ErrActivePartners,
+		},
+		{
+			test:           "Close a liquidated company",
+			from:           []entity.CompanyStatus{entity.StatusUnderLiquidation},
+			to:             entity.StatusClosed,
+			reason:         "Distrato registered",
+			expectedStatus: entity.StatusClosed,
+		},
+		{
+			test:           "Reopen a closed company",
+			from:           []entity.CompanyStatus{entity.StatusClosed},
+			to:             entity.StatusActive,
+			reason:         "Reopening",
+			expectedStatus: entity.StatusClosed,
+			expectedError:  entity.ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		comp, err := entity.NewCompany("", "01.234.567/0001-89", "Company Test", "Company Test Inc", "", "",
+			time.Now().Add(-time.Hour))
+		require.Nil(t, err)
+		require.Equal(t, entity.StatusActive, comp.Status)
+
+		for _, status := range tc.from {
+			require.Nil(t, comp.TransitionTo(status, "Setup", time.Time{}, entity.CompanyDependencies{}))
+		}
+		comp.PullEvents()
+
+		err = comp.TransitionTo(tc.to, tc.reason, time.Time{}, tc.deps)
+		require.Equal(t, tc.expectedStatus, comp.Status)
+
+		if tc.expectedError != nil {
+			require.True(t, errors.Is(err, tc.expectedError))
+			require.Len(t, comp.StatusHistory, len(tc.from))
+			require.Empty(t, comp.PullEvents())
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Len(t, comp.StatusHistory, len(tc.from)+1)
+		require.Equal(t, tc.reason, comp.StatusHistory[len(tc.from)].Reason)
+
+		raised := comp.PullEvents()
+		require.Len(t, raised, 1)
+		require.Equal(t, "company."+string(tc.to), raised[0].Name())
+		require.Empty(t, comp.PullEvents())
+	}
+}
@@ -3,20 +3,32 @@ package entity
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"errors"
 	"time"
 
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/cnae"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/events"
 	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
 	"github.com/google/uuid"
 )
 
+var ErrDuplicatedCNAE = errors.New("CNAE codes must not be repeated between primary and secondary activities")
+
 type Company struct {
-	ID                    string    `validate:"required,uuid"`
-	EIN                   string    `validate:"required"`
-	Name                  string    `validate:"required,min=3"`
-	FullName              string    `validate:"required,min=3"`
-	MunicipalRegistration string    `validate:""`
-	StateRegistration     string    `validate:""`
-	CreatedAt             time.Time `validate:"required"`
+	ID                    string             `validate:"required,uuid"`
+	EIN                   string             `validate:"required"`
+	Name                  string             `validate:"required,min=3"`
+	FullName              string             `validate:"required,min=3"`
+	MunicipalRegistration string             `validate:""`
+	StateRegistration     string             `validate:""`
+	TaxRegimes            []TaxRegimePeriod  `validate:"dive"`
+	PrimaryCNAE           string             `validate:"required_with=SecondaryCNAEs,omitempty,cnae"`
+	SecondaryCNAEs        []string           `validate:"dive,cnae"`
+	Status                CompanyStatus      `validate:"required,oneof=active suspended under_liquidation closed"`
+	StatusHistory         []StatusTransition `validate:"dive"`
+	CreatedAt             time.Time          `validate:"required"`
+
+	events []events.Event
 }
 
 func NewCompany(id string, ein string, name string, fullName string, municipalReg string, stateReg string,
@@ -37,12 +49,46 @@ func NewCompany(id string, ein string, name string, fullName string, municipalRe
 		FullName:              fullName,
 		MunicipalRegistration: municipalReg,
 		StateRegistration:     stateReg,
+		Status:                StatusActive,
 		CreatedAt:             createdAt,
 	}
 
 	return company, validateCompany(company)
 }
 
+// SetCNAEs replaces the company activities. Codes are accepted with or without punctuation
+// and stored in the official "0000-0/00" notation.
+func (c *Company) SetCNAEs(primary string, secondary ...string) error {
+	updated := c.clone()
+	updated.PrimaryCNAE = cnae.Format(primary)
+	updated.SecondaryCNAEs = nil
+
+	seen := map[string]bool{updated.PrimaryCNAE: true}
+	for _, code := range secondary {
+		code = cnae.Format(code)
+		if seen[code] {
+			return ErrDuplicatedCNAE
+		}
+
+		seen[code] = true
+		updated.SecondaryCNAEs = append(updated.SecondaryCNAEs, code)
+	}
+
+	if err := validateCompany(updated); err != nil {
+		return err
+	}
+
+	*c = updated
+	return nil
+}
+
+func (c Company) clone() Company {
+	c.TaxRegimes = append([]TaxRegimePeriod(nil), c.TaxRegimes...)
+	c.SecondaryCNAEs = append([]string(nil), c.SecondaryCNAEs...)
+	c.StatusHistory = append([]StatusTransition(nil), c.StatusHistory...)
+	return c
+}
+
 func validateCompany(c Company) error {
 	cv := validator.NewCustomValidate()
 	err := cv.Validate(c)
@@ -167,3 +167,59 @@ func TestCompany_NewCompany(t *testing.T) {
 		require.Nil(t, err)
 	}
 }
+
+func TestCompany_SetCNAEs(t *testing.T) {
+	type testCase struct {
+		test              string
+		primary           string
+		secondary         []string
+		expectedPrimary   string
+		expectedSecondary []string
+		expectedError     error
+	}
+
+	testsTable := []testCase{
+		{
+			test:          "Unknown primary CNAE error validation",
+			primary:       "9999-9/99",
+			expectedError: errors.New("invalid fields: Company.PrimaryCNAE: \"9999-9/99\""),
+		},
+		{
+			test:          "Unknown secondary CNAE error validation",
+			primary:       "4120-4/00",
+			secondary:     []string{"4110700", "12"},
+			expectedError: errors.New("invalid fields: Company.SecondaryCNAEs[1]: \"12\""),
+		},
+		{
+			test:          "Repeated CNAE error validation",
+			primary:       "4120400",
+			secondary:     []string{"4120-4/00"},
+			expectedError: entity.ErrDuplicatedCNAE,
+		},
+		{
+			test:              "Valid CNAEs normalized to the official notation",
+			primary:           "4120400",
+			secondary:         []string{"4110-7/00", "6810.2.01"},
+			expectedPrimary:   "4120-4/00",
+			expectedSecondary: []string{"4110-7/00", "6810-2/01"},
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		comp, err := entity.NewCompany("", "01.234.567/0001-89", "Company Test", "Company Test Inc", "", "", time.Time{})
+		require.Nil(t, err)
+
+		err = comp.SetCNAEs(tc.primary, tc.secondary...)
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			require.Empty(t, comp.PrimaryCNAE)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedPrimary, comp.PrimaryCNAE)
+		require.Equal(t, tc.expectedSecondary, comp.SecondaryCNAEs)
+	}
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+type TaxRegime string
+
+const (
+	SimplesNacional TaxRegime = "simples_nacional"
+	LucroPresumido  TaxRegime = "lucro_presumido"
+	LucroReal       TaxRegime = "lucro_real"
+)
+
+var (
+	ErrNoTaxRegime           = errors.New("company has no tax regime in force on the given date")
+	ErrTaxRegimeBeforeLatest = errors.New("tax regime must take effect after the current regime start date")
+)
+
+// TaxRegimePeriod is the regime in force from StartDate (inclusive) until EndDate (exclusive).
+// A zero EndDate means the period is still open.
+type TaxRegimePeriod struct {
+	Regime    TaxRegime `validate:"required,oneof=simples_nacional lucro_presumido lucro_real"`
+	StartDate time.Time `validate:"required"`
+	EndDate   time.Time `validate:"omitempty,gtfield=StartDate"`
+}
+
+func (p TaxRegimePeriod) Contains(date time.Time) bool {
+	if date.Before(p.StartDate) {
+		return false
+	}
+
+	return p.EndDate.IsZero() || date.Before(p.EndDate)
+}
+
+// ChangeTaxRegime closes the regime currently in force and opens a new one starting at effectiveDate.
+func (c *Company) ChangeTaxRegime(regime TaxRegime, effectiveDate time.Time) error {
+	period := TaxRegimePeriod{Regime: regime, StartDate: effectiveDate}
+
+	updated := c.clone()
+	if n := len(updated.TaxRegimes); n > 0 {
+		last := &updated.TaxRegimes[n-1]
+		if !effectiveDate.After(last.StartDate) {
+			return ErrTaxRegimeBeforeLatest
+		}
+
+		last.EndDate = effectiveDate
+	}
+
+	updated.TaxRegimes = append(updated.TaxRegimes, period)
+
+	if err := validateCompany(updated); err != nil {
+		return err
+	}
+
+	*c = updated
+	return nil
+}
+
+func (c Company) TaxRegimeAt(date time.Time) (TaxRegime, error) {
+	for _, p := range c.TaxRegimes {
+		if p.Contains(date) {
+			return p.Regime, nil
+		}
+	}
+
+	return "", ErrNoTaxRegime
+}
@@ -0,0 +1,62 @@
+package entity_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/company/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompany_TaxRegimeAt(t *testing.T) {
+	type testCase struct {
+		test           string
+		date           time.Time
+		expectedOutput entity.TaxRegime
+		expectedError  error
+	}
+
+	comp, err := entity.NewCompany("", "01.234.567/0001-89", "Company Test", "Company Test Inc", "", "", time.Time{})
+	require.Nil(t, err)
+
+	jan2022 := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	jan2024 := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Nil(t, comp.ChangeTaxRegime(entity.SimplesNacional, jan2022))
+	require.Nil(t, comp.ChangeTaxRegime(entity.LucroPresumido, jan2024))
+	require.Equal(t, entity.ErrTaxRegimeBeforeLatest, comp.ChangeTaxRegime(entity.LucroReal, jan2022))
+	require.Error(t, comp.ChangeTaxRegime("invalid", jan2024.AddDate(1, 0, 0)))
+	require.Len(t, comp.TaxRegimes, 2)
+
+	testsTable := []testCase{
+		{
+			test:          "Date before the first regime",
+			date:          jan2022.AddDate(0, 0, -1),
+			expectedError: entity.ErrNoTaxRegime,
+		},
+		{
+			test:           "First day of the first regime",
+			date:           jan2022,
+			expectedOutput: entity.SimplesNacional,
+		},
+		{
+			test:           "Last day of the first regime",
+			date:           jan2024.AddDate(0, 0, -1),
+			expectedOutput: entity.SimplesNacional,
+		},
+		{
+			test:           "Open regime",
+			date:           jan2024.AddDate(3, 0, 0),
+			expectedOutput: entity.LucroPresumido,
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		regime, err := comp.TaxRegimeAt(tc.date)
+
+		require.Equal(t, tc.expectedOutput, regime)
+		require.Equal(t, tc.expectedError, err)
+	}
+}
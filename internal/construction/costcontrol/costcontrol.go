@@ -0,0 +1,176 @@
+package costcontrol
+
+import (
+	"errors"
+	"fmt"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	financial "github.com/LHS-Real-Estate/cim-core/internal/financial/entity"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultAlertThreshold raises an alert once a stage consumes its whole budget.
+func DefaultAlertThreshold() decimal.Decimal {
+	return decimal.NewFromInt(100)
+}
+
+var (
+	ErrInvalidThreshold  = errors.New("alert threshold must be greater than zero")
+	ErrUnknownBudgetItem = errors.New("expenditure references an item that is not in the budget")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Figures are the budget against actual amounts of a stage or of the whole budget. Committed
+// only counts purchase orders not paid yet, so Committed + Spent is what is already consumed.
+type Figures struct {
+	Budgeted        decimal.Decimal
+	Committed       decimal.Decimal
+	Spent           decimal.Decimal
+	Variance        decimal.Decimal
+	PercentConsumed decimal.Decimal
+}
+
+func (f Figures) Consumed() decimal.Decimal {
+	return f.Committed.Add(f.Spent)
+}
+
+func (f *Figures) add(e financial.Expenditure) {
+	switch e.Status {
+	case financial.ExpenditureCommitted:
+		f.Committed = f.Committed.Add(e.Amount)
+	case financial.ExpenditurePaid:
+		f.Spent = f.Spent.Add(e.Amount)
+	}
+}
+
+func (f *Figures) close() {
+	f.Variance = f.Budgeted.Sub(f.Consumed())
+	if f.Budgeted.IsPositive() {
+		f.PercentConsumed = f.Consumed().Mul(hundred).Div(f.Budgeted).Round(2)
+	} else if f.Consumed().IsPositive() {
+		f.PercentConsumed = hundred
+	}
+}
+
+func (f Figures) overBudget() bool {
+	return !f.Budgeted.IsPositive() && f.Consumed().IsPositive()
+}
+
+// StageReport figures include the sub stages of the stage.
+type StageReport struct {
+	StageID  string
+	Code     string
+	Name     string
+	ParentID string
+	Figures
+}
+
+type Alert struct {
+	StageID         string
+	Code            string
+	Name            string
+	PercentConsumed decimal.Decimal
+	Threshold       decimal.Decimal
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("stage %s %s consumed %s%% of its budget (threshold %s%%)", a.Code, a.Name,
+		a.PercentConsumed.StringFixed(2), a.Threshold.String())
+}
+
+type Report struct {
+	ConstructionID string
+	BudgetID       string
+	Stages         []StageReport
+	Total          Figures
+	Alerts         []Alert
+}
+
+func (r Report) Stage(stageID string) (StageReport, bool) {
+	for _, s := range r.Stages {
+		if s.StageID == stageID {
+			return s, true
+		}
+	}
+	return StageReport{}, false
+}
+
+// Engine compares a budget with the expenditures of its construction.
+type Engine struct {
+	threshold decimal.Decimal
+}
+
+// NewEngine creates an engine alerting on stages whose consumption, in percent of the stage
+// budget, reaches the threshold. Stages without budget alert as soon as they consume anything.
+func NewEngine(threshold decimal.Decimal) (*Engine, error) {
+	if !threshold.IsPositive() {
+		return nil, ErrInvalidThreshold
+	}
+	return &Engine{threshold: threshold}, nil
+}
+
+// Report aggregates the expenditures per stage of the budget. Budgeted amounts are direct
+// costs, without BDI, since expenditures are costs as well. Expenditures of other
+// constructions and cancelled ones are ignored.
+func (e *Engine) Report(budget construction.Budget, expenditures []financial.Expenditure) (Report, error) {
+	byItem := make(map[string][]financial.Expenditure)
+	for _, exp := range expenditures {
+		if exp.ConstructionID != budget.ConstructionID || exp.Status == financial.ExpenditureCancelled {
+			continue
+		}
+
+		if _, ok := budget.Item(exp.BudgetItemID); !ok {
+			return Report{}, fmt.Errorf("%w: %s", ErrUnknownBudgetItem, exp.ID)
+		}
+
+		byItem[exp.BudgetItemID] = append(byItem[exp.BudgetItemID], exp)
+	}
+
+	r := Report{ConstructionID: budget.ConstructionID, BudgetID: budget.ID}
+	for _, s := range budget.Stages {
+		f := e.reportStage(&r, s, "", byItem)
+		r.Total.Budgeted = r.Total.Budgeted.Add(f.Budgeted)
+		r.Total.Committed = r.Total.Committed.Add(f.Committed)
+		r.Total.Spent = r.Total.Spent.Add(f.Spent)
+	}
+	r.Total.close()
+
+	return r, nil
+}
+
+// reportStage appends the report of the stage before the ones of its sub stages and returns
+// the stage figures.
+func (e *Engine) reportStage(r *Report, s construction.BudgetStage, parentID string,
+	byItem map[string][]financial.Expenditure) Figures {
+
+	idx := len(r.Stages)
+	r.Stages = append(r.Stages, StageReport{StageID: s.ID, Code: s.Code, Name: s.Name, ParentID: parentID})
+
+	f := Figures{Budgeted: s.Total()}
+	for _, item := range s.Items {
+		for _, exp := range byItem[item.ID] {
+			f.add(exp)
+		}
+	}
+
+	for _, sub := range s.Stages {
+		sf := e.reportStage(r, sub, s.ID, byItem)
+		f.Committed = f.Committed.Add(sf.Committed)
+		f.Spent = f.Spent.Add(sf.Spent)
+	}
+	f.close()
+
+	r.Stages[idx].Figures = f
+	if f.overBudget() || f.PercentConsumed.GreaterThanOrEqual(e.threshold) {
+		r.Alerts = append(r.Alerts, Alert{
+			StageID:         s.ID,
+			Code:            s.Code,
+			Name:            s.Name,
+			PercentConsumed: f.PercentConsumed,
+			Threshold:       e.threshold,
+		})
+	}
+
+	return f
+}
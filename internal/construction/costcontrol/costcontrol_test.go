@@ -0,0 +1,160 @@
+package costcontrol_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/costcontrol"
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	financial "github.com/LHS-Real-Estate/cim-core/internal/financial/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestEngine_Report(t *testing.T) {
+	companyID := uuid.New().String()
+	b, err := construction.NewBudget("", uuid.New().String(), dec("25"), time.Time{})
+	require.Nil(t, err)
+
+	structure, err := b.AddStage("", "1", "Estrutura")
+	require.Nil(t, err)
+	foundation, err := b.AddStage(structure.ID, "1.1", "Fundação")
+	require.Nil(t, err)
+	finishing, err := b.AddStage("", "2", "Acabamento")
+	require.Nil(t, err)
+	site, err := b.AddStage("", "3", "Canteiro")
+	require.Nil(t, err)
+
+	concrete, err := b.AddItem(foundation.ID, construction.BudgetItem{
+		Code: "1.1.1", Description: "Concreto usinado", Unit: "m3", Quantity: dec("20"), UnitCost: dec("500"),
+	})
+	require.Nil(t, err)
+	painting, err := b.AddItem(finishing.ID, construction.BudgetItem{
+		Code: "2.1", Description: "Pintura", Unit: "m2", Quantity: dec("400"), UnitCost: dec("25"),
+	})
+	require.Nil(t, err)
+	power, err := b.AddItem(site.ID, construction.BudgetItem{
+		Code: "3.1", Description: "Ligação provisória de energia", Unit: "un", Quantity: dec("1"), UnitCost: dec("0"),
+	})
+	require.Nil(t, err)
+
+	expenditure := func(constructionID string, itemID string, amount string, paid bool) financial.Expenditure {
+		e, err := financial.NewExpenditure("", companyID, constructionID, itemID, "Compra", "", dec(amount),
+			time.Time{}, time.Time{})
+		require.Nil(t, err)
+		if paid {
+			require.Nil(t, e.Pay(time.Time{}))
+		}
+		return e
+	}
+
+	cancelled := expenditure(b.ConstructionID, painting.ID, "5000", false)
+	require.Nil(t, cancelled.Cancel())
+
+	expenditures := []financial.Expenditure{
+		expenditure(b.ConstructionID, concrete.ID, "6000", true),
+		expenditure(b.ConstructionID, concrete.ID, "4500", false),
+		expenditure(b.ConstructionID, painting.ID, "2500", true),
+		expenditure(b.ConstructionID, power.ID, "800", true),
+		expenditure(uuid.New().String(), painting.ID, "9999", true),
+		cancelled,
+	}
+
+	type testCase struct {
+		test           string
+		threshold      decimal.Decimal
+		expenditures   []financial.Expenditure
+		expectedAlerts []string
+		expectedErr    error
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should reject a threshold that is not positive",
+			threshold:   decimal.Zero,
+			expectedErr: costcontrol.ErrInvalidThreshold,
+		},
+		{
+			test:         "Should reject expenditures of items outside the budget",
+			threshold:    costcontrol.DefaultAlertThreshold(),
+			expenditures: []financial.Expenditure{expenditure(b.ConstructionID, uuid.New().String(), "10", false)},
+			expectedErr:  costcontrol.ErrUnknownBudgetItem,
+		},
+		{
+			test:           "Should alert the overrun stage, its parent and the stage without budget",
+			threshold:      costcontrol.DefaultAlertThreshold(),
+			expenditures:   expenditures,
+			expectedAlerts: []string{"1.1", "1", "3"},
+		},
+		{
+			test:           "Should alert every stage over a lower threshold",
+			threshold:      dec("20"),
+			expenditures:   expenditures,
+			expectedAlerts: []string{"1.1", "1", "2", "3"},
+		},
+		{
+			test:           "Should alert a stage that reaches the threshold exactly",
+			threshold:      dec("25"),
+			expenditures:   expenditures,
+			expectedAlerts: []string{"1.1", "1", "2", "3"},
+		},
+		{
+			test:           "Should alert spending without budget over any threshold",
+			threshold:      dec("150"),
+			expenditures:   expenditures,
+			expectedAlerts: []string{"3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		engine, err := costcontrol.NewEngine(tc.threshold)
+		if err == nil {
+			var r costcontrol.Report
+			r, err = engine.Report(b, tc.expenditures)
+			if err == nil {
+				var alerts []string
+				for _, a := range r.Alerts {
+					alerts = append(alerts, a.Code)
+				}
+				require.Equal(t, tc.expectedAlerts, alerts)
+			}
+		}
+
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr))
+			continue
+		}
+		require.Nil(t, err)
+	}
+
+	engine, err := costcontrol.NewEngine(costcontrol.DefaultAlertThreshold())
+	require.Nil(t, err)
+	r, err := engine.Report(b, expenditures)
+	require.Nil(t, err)
+
+	stage, ok := r.Stage(structure.ID)
+	require.True(t, ok)
+	require.True(t, dec("10000").Equal(stage.Budgeted))
+	require.True(t, dec("4500").Equal(stage.Committed))
+	require.True(t, dec("6000").Equal(stage.Spent))
+	require.True(t, dec("-500").Equal(stage.Variance))
+	require.True(t, dec("105").Equal(stage.PercentConsumed))
+
+	stage, ok = r.Stage(finishing.ID)
+	require.True(t, ok)
+	require.True(t, dec("7500").Equal(stage.Variance))
+	require.True(t, dec("25").Equal(stage.PercentConsumed))
+
+	require.True(t, dec("20000").Equal(r.Total.Budgeted))
+	require.True(t, dec("13800").Equal(r.Total.Consumed()))
+	require.True(t, dec("69").Equal(r.Total.PercentConsumed))
+}
@@ -0,0 +1,478 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type BudgetStatus string
+
+const (
+	BudgetDraft      BudgetStatus = "draft"
+	BudgetApproved   BudgetStatus = "approved"
+	BudgetSuperseded BudgetStatus = "superseded"
+)
+
+// Money amounts are rounded to cents item by item, the same way the budget is printed.
+const moneyPlaces = 2
+
+var (
+	ErrBudgetLocked       = errors.New("budget is not a draft and cannot be edited, create a new revision instead")
+	ErrBudgetNotApproved  = errors.New("budget is not approved")
+	ErrBudgetEmpty        = errors.New("budget has no items")
+	ErrBudgetTampered     = errors.New("approved budget content does not match its checksum")
+	ErrStageNotFound      = errors.New("budget stage not found")
+	ErrBudgetItemNotFound = errors.New("budget item not found")
+	ErrDuplicatedItemID   = errors.New("budget already has an item with this ID")
+)
+
+type Budget struct {
+	ID                string          `validate:"required,uuid"`
+	ConstructionID    string          `validate:"required,uuid"`
+	Version           int             `validate:"gte=1"`
+	PreviousVersionID string          `validate:"omitempty,uuid"`
+	Status            BudgetStatus    `validate:"required,oneof=draft approved superseded"`
+	BDI               decimal.Decimal `validate:"gte=0,lte=100"`
+	Stages            []BudgetStage   `validate:"dive"`
+	ApprovedBy        string          `validate:"required_unless=Status draft"`
+	ApprovedAt        time.Time       `validate:"required_unless=Status draft"`
+	Checksum          string          `validate:"required_unless=Status draft"`
+	CreatedAt         time.Time       `validate:"required"`
+}
+
+// BudgetStage is a node of the cost breakdown structure (EAP). It may hold both sub stages
+// and items.
+type BudgetStage struct {
+	ID     string        `validate:"required,uuid"`
+	Code   string        `validate:"required"`
+	Name   string        `validate:"required,min=2"`
+	Stages []BudgetStage `validate:"dive"`
+	Items  []BudgetItem  `validate:"dive"`
+}
+
+// BudgetItem is priced either by its UnitCost or, when it has one, by its unit composition.
+// Items priced from a reference catalog keep the reference price they were priced with.
+type BudgetItem struct {
+	ID          string             `validate:"required,uuid"`
+	Code        string             `validate:"required"`
+	Description string             `validate:"required,min=2"`
+	Unit        string             `validate:"required,max=10"`
+	Quantity    decimal.Decimal    `validate:"gt=0"`
+	UnitCost    decimal.Decimal    `validate:"gte=0"`
+	Composition []CompositionInput `validate:"dive"`
+	Reference   *PriceReference    `validate:"omitempty"`
+}
+
+// PriceReference is a snapshot of a reference catalog price, such as SINAPI, so later catalog
+// imports do not change a budget already priced.
+type PriceReference struct {
+	Source     string          `validate:"required"`
+	Code       string          `validate:"required"`
+	State      string          `validate:"required,len=2"`
+	Month      time.Time       `validate:"required"`
+	TaxExempt  bool            `validate:"-"`
+	UnitPrice  decimal.Decimal `validate:"gte=0"`
+	SnapshotAt time.Time       `validate:"required"`
+}
+
+// CompositionInput is an input (material, labor or equipment) consumed per unit of the item.
+type CompositionInput struct {
+	Code        string          `validate:"required"`
+	Description string          `validate:"required,min=2"`
+	Unit        string          `validate:"required,max=10"`
+	Coefficient decimal.Decimal `validate:"gt=0"`
+	UnitPrice   decimal.Decimal `validate:"gte=0"`
+}
+
+func NewBudget(id string, constructionID string, bdi decimal.Decimal, createdAt time.Time) (Budget, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	b := Budget{
+		ID:             id,
+		ConstructionID: constructionID,
+		Version:        1,
+		Status:         BudgetDraft,
+		BDI:            bdi,
+		CreatedAt:      createdAt,
+	}
+
+	return b, validateBudget(b)
+}
+
+func (i BudgetItem) UnitCostWithoutBDI() decimal.Decimal {
+	if len(i.Composition) == 0 {
+		return i.UnitCost
+	}
+
+	cost := decimal.Zero
+	for _, input := range i.Composition {
+		cost = cost.Add(input.Coefficient.Mul(input.UnitPrice))
+	}
+	return cost.Round(moneyPlaces)
+}
+
+func (i BudgetItem) Total() decimal.Decimal {
+	return i.Quantity.Mul(i.UnitCostWithoutBDI()).Round(moneyPlaces)
+}
+
+func (s BudgetStage) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, child := range s.Stages {
+		total = total.Add(child.Total())
+	}
+	for _, item := range s.Items {
+		total = total.Add(item.Total())
+	}
+	return total
+}
+
+// DirectCost is the sum of every item, before the BDI markup.
+func (b Budget) DirectCost() decimal.Decimal {
+	total := decimal.Zero
+	for _, s := range b.Stages {
+		total = total.Add(s.Total())
+	}
+	return total
+}
+
+func (b Budget) BDIAmount() decimal.Decimal {
+	return b.DirectCost().Mul(b.BDI).Div(decimal.NewFromInt(100)).Round(moneyPlaces)
+}
+
+func (b Budget) Total() decimal.Decimal {
+	return b.DirectCost().Add(b.BDIAmount())
+}
+
+// WithBDI applies the budget markup to a direct cost.
+func (b Budget) WithBDI(cost decimal.Decimal) decimal.Decimal {
+	return cost.Mul(decimal.NewFromInt(100).Add(b.BDI)).Div(decimal.NewFromInt(100)).Round(moneyPlaces)
+}
+
+// AddStage adds a stage under parentID, or at the root when parentID is empty.
+func (b *Budget) AddStage(parentID string, code string, name string) (BudgetStage, error) {
+	stage := BudgetStage{ID: uuid.New().String(), Code: code, Name: name}
+
+	err := b.edit(func(u *Budget) error {
+		if parentID == "" {
+			u.Stages = append(u.Stages, stage)
+			return nil
+		}
+
+		parent := findStage(u.Stages, parentID)
+		if parent == nil {
+			return ErrStageNotFound
+		}
+		parent.Stages = append(parent.Stages, stage)
+		return nil
+	})
+
+	return stage, err
+}
+
+func (b *Budget) AddItem(stageID string, item BudgetItem) (BudgetItem, error) {
+	if item.ID == "" {
+		item.ID = uuid.New().String()
+	}
+
+	err := b.edit(func(u *Budget) error {
+		stage := findStage(u.Stages, stageID)
+		if stage == nil {
+			return ErrStageNotFound
+		}
+
+		if findItem(u.Stages, item.ID) != nil {
+			return ErrDuplicatedItemID
+		}
+		stage.Items = append(stage.Items, item)
+		return nil
+	})
+
+	return item, err
+}
+
+func (b *Budget) UpdateItem(item BudgetItem) error {
+	return b.edit(func(u *Budget) error {
+		current := findItem(u.Stages, item.ID)
+		if current == nil {
+			return ErrBudgetItemNotFound
+		}
+		*current = item
+		return nil
+	})
+}
+
+// PriceItemFromReference prices the item with a reference catalog price, replacing its
+// unit cost and composition.
+func (b *Budget) PriceItemFromReference(itemID string, ref PriceReference) error {
+	return b.edit(func(u *Budget) error {
+		item := findItem(u.Stages, itemID)
+		if item == nil {
+			return ErrBudgetItemNotFound
+		}
+
+		item.UnitCost = ref.UnitPrice
+		item.Composition = nil
+		item.Reference = &ref
+		return nil
+	})
+}
+
+func (b *Budget) RemoveItem(itemID string) error {
+	return b.edit(func(u *Budget) error {
+		if !removeItem(u.Stages, itemID) {
+			return ErrBudgetItemNotFound
+		}
+		return nil
+	})
+}
+
+func (b *Budget) SetBDI(bdi decimal.Decimal) error {
+	return b.edit(func(u *Budget) error {
+		u.BDI = bdi
+		return nil
+	})
+}
+
+// Approve locks the budget as the baseline of the construction. Any later change requires a new revision.
+func (b *Budget) Approve(approvedBy string, at time.Time) error {
+	if b.Status != BudgetDraft {
+		return ErrBudgetLocked
+	}
+
+	if len(b.Items()) == 0 {
+		return ErrBudgetEmpty
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	updated := b.clone()
+	updated.Status = BudgetApproved
+	updated.ApprovedBy = approvedBy
+	updated.ApprovedAt = at
+	updated.Checksum = updated.contentChecksum()
+
+	if err := validateBudget(updated); err != nil {
+		return err
+	}
+
+	*b = updated
+	return nil
+}
+
+// NewRevision starts the next version of an approved budget as a draft with the same content.
+// The approved budget is kept untouched until Supersede is called on it.
+func (b Budget) NewRevision(at time.Time) (Budget, error) {
+	if b.Status != BudgetApproved {
+		return Budget{}, ErrBudgetNotApproved
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	revision := b.clone()
+	revision.ID = uuid.New().String()
+	revision.Version = b.Version + 1
+	revision.PreviousVersionID = b.ID
+	revision.Status = BudgetDraft
+	revision.ApprovedBy = ""
+	revision.ApprovedAt = time.Time{}
+	revision.Checksum = ""
+	revision.CreatedAt = at
+
+	if err := validateBudget(revision); err != nil {
+		return Budget{}, err
+	}
+
+	return revision, nil
+}
+
+// Supersede retires an approved budget once its revision replaces it.
+func (b *Budget) Supersede() error {
+	if b.Status != BudgetApproved {
+		return ErrBudgetNotApproved
+	}
+
+	updated := b.clone()
+	updated.Status = BudgetSuperseded
+
+	if err := validateBudget(updated); err != nil {
+		return err
+	}
+
+	*b = updated
+	return nil
+}
+
+// VerifyIntegrity detects changes made to an approved budget without going through a revision.
+func (b Budget) VerifyIntegrity() error {
+	if b.Status == BudgetDraft {
+		return nil
+	}
+
+	if b.contentChecksum() != b.Checksum {
+		return ErrBudgetTampered
+	}
+	return nil
+}
+
+// Items lists every item of the budget, depth first.
+func (b Budget) Items() []BudgetItem {
+	var items []BudgetItem
+	walkStages(b.Stages, func(s *BudgetStage) {
+		items = append(items, s.Items...)
+	})
+	return items
+}
+
+func (b Budget) Item(itemID string) (BudgetItem, bool) {
+	stages := b.clone().Stages
+	item := findItem(stages, itemID)
+	if item == nil {
+		return BudgetItem{}, false
+	}
+	return *item, true
+}
+
+func (b Budget) Stage(stageID string) (BudgetStage, bool) {
+	stages := b.clone().Stages
+	stage := findStage(stages, stageID)
+	if stage == nil {
+		return BudgetStage{}, false
+	}
+	return *stage, true
+}
+
+func (b *Budget) edit(change func(u *Budget) error) error {
+	if b.Status != BudgetDraft {
+		return ErrBudgetLocked
+	}
+
+	updated := b.clone()
+	if err := change(&updated); err != nil {
+		return err
+	}
+
+	if err := validateBudget(updated); err != nil {
+		return err
+	}
+
+	*b = updated
+	return nil
+}
+
+// contentChecksum hashes the reference dates in UTC, so a budget reloaded in another time zone
+// keeps its checksum.
+func (b Budget) contentChecksum() string {
+	stages := cloneStages(b.Stages)
+	walkStages(stages, func(s *BudgetStage) {
+		for i := range s.Items {
+			if ref := s.Items[i].Reference; ref != nil {
+				ref.Month = ref.Month.UTC()
+				ref.SnapshotAt = ref.SnapshotAt.UTC()
+			}
+		}
+	})
+
+	content, _ := json.Marshal(struct {
+		ConstructionID string
+		Version        int
+		BDI            decimal.Decimal
+		Stages         []BudgetStage
+	}{b.ConstructionID, b.Version, b.BDI, stages})
+
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func (b Budget) clone() Budget {
+	b.Stages = cloneStages(b.Stages)
+	return b
+}
+
+func cloneStages(stages []BudgetStage) []BudgetStage {
+	if stages == nil {
+		return nil
+	}
+
+	cloned := make([]BudgetStage, len(stages))
+	for i, s := range stages {
+		s.Stages = cloneStages(s.Stages)
+		s.Items = append([]BudgetItem(nil), s.Items...)
+		for j := range s.Items {
+			s.Items[j].Composition = append([]CompositionInput(nil), s.Items[j].Composition...)
+			if ref := s.Items[j].Reference; ref != nil {
+				copied := *ref
+				s.Items[j].Reference = &copied
+			}
+		}
+		cloned[i] = s
+	}
+	return cloned
+}
+
+func walkStages(stages []BudgetStage, visit func(s *BudgetStage)) {
+	for i := range stages {
+		visit(&stages[i])
+		walkStages(stages[i].Stages, visit)
+	}
+}
+
+func findStage(stages []BudgetStage, id string) *BudgetStage {
+	var found *BudgetStage
+	walkStages(stages, func(s *BudgetStage) {
+		if found == nil && s.ID == id {
+			found = s
+		}
+	})
+	return found
+}
+
+func findItem(stages []BudgetStage, id string) *BudgetItem {
+	var found *BudgetItem
+	walkStages(stages, func(s *BudgetStage) {
+		for i := range s.Items {
+			if found == nil && s.Items[i].ID == id {
+				found = &s.Items[i]
+			}
+		}
+	})
+	return found
+}
+
+func removeItem(stages []BudgetStage, id string) bool {
+	removed := false
+	walkStages(stages, func(s *BudgetStage) {
+		for i := range s.Items {
+			if !removed && s.Items[i].ID == id {
+				s.Items = append(s.Items[:i], s.Items[i+1:]...)
+				removed = true
+				return
+			}
+		}
+	})
+	return removed
+}
+
+func validateBudget(b Budget) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(b)
+
+	return err
+}
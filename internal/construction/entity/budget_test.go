@@ -0,0 +1,194 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestBudget_NewBudget(t *testing.T) {
+	type testCase struct {
+		test           string
+		constructionID string
+		bdi            decimal.Decimal
+		expectedError  error
+	}
+
+	testsTable := []testCase{
+		{
+			test:          "Empty ConstructionID and negative BDI error validation",
+			bdi:           dec("-1"),
+			expectedError: errors.New("invalid fields: Budget.ConstructionID: \"\", Budget.BDI: \"-1\""),
+		},
+		{
+			test:           "Valid Budget fields",
+			constructionID: uuid.New().String(),
+			bdi:            dec("25.5"),
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		b, err := entity.NewBudget("", tc.constructionID, tc.bdi, time.Time{})
+
+		require.NotEmpty(t, b.ID)
+		require.Equal(t, 1, b.Version)
+		require.Equal(t, entity.BudgetDraft, b.Status)
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+}
+
+func TestBudget_Totals(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("25"), time.Time{})
+	require.Nil(t, err)
+
+	structure, err := b.AddStage("", "1", "Estrutura")
+	require.Nil(t, err)
+	foundations, err := b.AddStage(structure.ID, "1.1", "Fundações")
+	require.Nil(t, err)
+	masonry, err := b.AddStage("", "2", "Alvenaria")
+	require.Nil(t, err)
+
+	_, err = b.AddStage(uuid.New().String(), "3", "Cobertura")
+	require.Equal(t, entity.ErrStageNotFound, err)
+
+	concrete, err := b.AddItem(foundations.ID, entity.BudgetItem{
+		Code:        "1.1.1",
+		Description: "Concreto fck 30 MPa",
+		Unit:        "m3",
+		Quantity:    dec("12.5"),
+		Composition: []entity.CompositionInput{
+			{Code: "34493", Description: "Concreto usinado", Unit: "m3", Coefficient: dec("1.05"), UnitPrice: dec("512.37")},
+			{Code: "88309", Description: "Pedreiro com encargos", Unit: "h", Coefficient: dec("0.333"), UnitPrice: dec("29.11")},
+		},
+	})
+	require.Nil(t, err)
+	// 1.05 * 512.37 + 0.333 * 29.11 = 537.9885 + 9.69363 = 547.68213 -> 547.68
+	require.True(t, dec("547.68").Equal(concrete.UnitCostWithoutBDI()))
+	// 12.5 * 547.68 = 6846.00
+	require.True(t, dec("6846").Equal(concrete.Total()))
+
+	_, err = b.AddItem(masonry.ID, entity.BudgetItem{
+		Code:        "2.1",
+		Description: "Alvenaria de bloco cerâmico",
+		Unit:        "m2",
+		Quantity:    dec("310.4"),
+		UnitCost:    dec("78.93"),
+	})
+	require.Nil(t, err)
+
+	_, err = b.AddItem(masonry.ID, entity.BudgetItem{Code: "2.2", Description: "Verga", Unit: "m"})
+	require.Equal(t, errors.New("invalid fields: Budget.Stages[1].Items[1].Quantity: \"0\""), err)
+
+	_, err = b.AddItem(masonry.ID, entity.BudgetItem{
+		ID: concrete.ID, Code: "2.2", Description: "Verga", Unit: "m", Quantity: dec("12"), UnitCost: dec("40"),
+	})
+	require.Equal(t, entity.ErrDuplicatedItemID, err)
+
+	// 310.4 * 78.93 = 24499.872 -> 24499.87
+	stage, ok := b.Stage(masonry.ID)
+	require.True(t, ok)
+	require.True(t, dec("24499.87").Equal(stage.Total()))
+
+	stage, ok = b.Stage(structure.ID)
+	require.True(t, ok)
+	require.True(t, dec("6846").Equal(stage.Total()))
+
+	require.True(t, dec("31345.87").Equal(b.DirectCost()))
+	// 31345.87 * 25% = 7836.4675 -> 7836.47
+	require.True(t, dec("7836.47").Equal(b.BDIAmount()))
+	require.True(t, dec("39182.34").Equal(b.Total()))
+	require.True(t, dec("684.60").Equal(b.WithBDI(concrete.UnitCostWithoutBDI())))
+}
+
+func TestBudget_ChecksumInAnotherTimeZone(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Estrutura")
+	require.Nil(t, err)
+	item, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Concreto fck 25 MPa", Unit: "m3", Quantity: dec("12"), UnitCost: dec("500"),
+	})
+	require.Nil(t, err)
+	require.Nil(t, b.PriceItemFromReference(item.ID, entity.PriceReference{
+		Source: "SINAPI", Code: "94965", State: "SP", Month: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		UnitPrice: dec("487.5"), SnapshotAt: time.Date(2023, 7, 10, 15, 0, 0, 0, time.UTC),
+	}))
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+
+	brt := time.FixedZone("BRT", -3*60*60)
+	reloaded := b
+	reloaded.Stages = []entity.BudgetStage{b.Stages[0]}
+	reloaded.Stages[0].Items = []entity.BudgetItem{b.Stages[0].Items[0]}
+	ref := *b.Stages[0].Items[0].Reference
+	ref.Month, ref.SnapshotAt = ref.Month.In(brt), ref.SnapshotAt.In(brt)
+	reloaded.Stages[0].Items[0].Reference = &ref
+
+	require.Nil(t, reloaded.VerifyIntegrity())
+}
+
+func TestBudget_Versioning(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+
+	require.Equal(t, entity.ErrBudgetEmpty, b.Approve("engineer@lhs", time.Time{}))
+
+	stage, err := b.AddStage("", "1", "Serviços preliminares")
+	require.Nil(t, err)
+	item, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Tapume", Unit: "m2", Quantity: dec("80"), UnitCost: dec("95.2"),
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+	require.NotEmpty(t, b.Checksum)
+	require.Nil(t, b.VerifyIntegrity())
+
+	item.Quantity = dec("100")
+	require.Equal(t, entity.ErrBudgetLocked, b.UpdateItem(item))
+	require.Equal(t, entity.ErrBudgetLocked, b.SetBDI(dec("30")))
+	require.Equal(t, entity.ErrBudgetLocked, b.RemoveItem(item.ID))
+
+	tampered := b
+	tampered.BDI = dec("30")
+	require.Equal(t, entity.ErrBudgetTampered, tampered.VerifyIntegrity())
+
+	revision, err := b.NewRevision(time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, 2, revision.Version)
+	require.Equal(t, b.ID, revision.PreviousVersionID)
+	require.Equal(t, entity.BudgetDraft, revision.Status)
+
+	_, err = revision.NewRevision(time.Time{})
+	require.Equal(t, entity.ErrBudgetNotApproved, err)
+	require.Equal(t, entity.ErrBudgetNotApproved, revision.Supersede())
+
+	require.Nil(t, revision.UpdateItem(item))
+	require.Nil(t, revision.Approve("engineer@lhs", time.Time{}))
+	require.Nil(t, b.Supersede())
+	require.Equal(t, entity.BudgetSuperseded, b.Status)
+
+	_, err = b.NewRevision(time.Time{})
+	require.Equal(t, entity.ErrBudgetNotApproved, err)
+	require.Equal(t, entity.ErrBudgetNotApproved, b.Supersede())
+
+	require.True(t, dec("9139.20").Equal(b.Total()))
+	require.True(t, dec("11424").Equal(revision.Total()))
+	require.Nil(t, b.VerifyIntegrity())
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"path"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+)
+
+type ConstructionDocument struct {
+	ID             string                `validate:"required,uuid"`
+	ConstructionID string                `validate:"required,uuid"`
+	Title          string                `validate:"required,min=3"`
+	File           valueobjects.Document `validate:"required"`
+	LastUpdated    time.Time             `validate:"required,gtefield=CreatedAt"`
+	CreatedAt      time.Time             `validate:"required,ltefield=LastUpdated"`
+}
+
+func NewDocument(id string, constructionID string, title string, filePath string, fileExtension string,
+	lastUpdated time.Time, createdAt time.Time) (ConstructionDocument, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if filePath == "" {
+		filePath = path.Join(ConstructionRootPath(constructionID), DocumentEncryptedName(id, fileExtension))
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	if lastUpdated.IsZero() {
+		lastUpdated = time.Now()
+	}
+
+	docFile := valueobjects.Document{FilePath: filePath, Extension: fileExtension}
+
+	document := ConstructionDocument{
+		ID:             id,
+		ConstructionID: constructionID,
+		Title:          title,
+		File:           docFile,
+		LastUpdated:    lastUpdated,
+		CreatedAt:      createdAt,
+	}
+
+	return document, validateConstructionDoc(document)
+}
+
+func validateConstructionDoc(cd ConstructionDocument) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(cd)
+	return err
+}
+
+func DocumentEncryptedName(docID string, extension string) string {
+	return valueobjects.DocumentEncryptedName(docID, extension)
+}
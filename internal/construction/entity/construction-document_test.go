@@ -0,0 +1,176 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionDocument_NewDocument(t *testing.T) {
+	type input_output struct {
+		id             string
+		constructionID string
+		title          string
+		filePath       string
+		extension      string
+		lastUpdated    time.Time
+		createdAt      time.Time
+	}
+
+	type testCase struct {
+		test           string
+		input          input_output
+		expectedOutput input_output
+		expectedError  error
+	}
+
+	testId := uuid.New().String()
+	timeNow := time.Now()
+	timeBefore := timeNow.Add(time.Minute * -1)
+
+	testsTable := []testCase{
+		{
+			test:           "Empty ConstructionID, Title and file extension error validation",
+			input:          input_output{},
+			expectedOutput: input_output{},
+			expectedError:  errors.New("invalid fields: ConstructionDocument.ConstructionID: \"\", ConstructionDocument.Title: \"\", ConstructionDocument.File.Extension: \"\""),
+		},
+		{
+			test: "ConstructionDocument ID, ConstructionID and Title length error validation",
+			input: input_output{
+				id:             "Invalid ID",
+				constructionID: "Invalid Construction ID",
+				title:          "AA",
+				filePath:       "path/to/file.pdf",
+				extension:      "pdf",
+				createdAt:      timeNow,
+				lastUpdated:    timeNow,
+			},
+			expectedOutput: input_output{
+				id:             "Invalid ID",
+				constructionID: "Invalid Construction ID",
+				title:          "AA",
+				filePath:       "path/to/file.pdf",
+				extension:      "pdf",
+				createdAt:      timeNow,
+				lastUpdated:    timeNow,
+			},
+			expectedError: errors.New("invalid fields: ConstructionDocument.ID: \"Invalid ID\", ConstructionDocument.ConstructionID: \"Invalid Construction ID\", ConstructionDocument.Title: \"AA\""),
+		},
+		{
+			test: "ConstructionDocument CreatedAt and LastUpdated error validation",
+			input: input_output{
+				id:             testId,
+				constructionID: testId,
+				title:          "Construction document",
+				filePath:       "path/to/file.pdf",
+				extension:      "pdf",
+				createdAt:      timeNow,
+				lastUpdated:    timeBefore,
+			},
+			expectedOutput: input_output{
+				id:             testId,
+				constructionID: testId,
+				title:          "Construction document",
+				filePath:       "path/to/file.pdf",
+				extension:      "pdf",
+				createdAt:      timeNow,
+				lastUpdated:    timeBefore,
+			},
+			expectedError: fmt.Errorf("invalid fields: ConstructionDocument.LastUpdated: \"%s\", ConstructionDocument.CreatedAt: \"%s\"", timeBefore, timeNow),
+		},
+		{
+			test: "Valid ConstructionDocument fields generating new ID, FilePath, CreatedAt and LastUpdated when empty",
+			input: input_output{
+				id:             "",
+				constructionID: testId,
+				title:          "Construction document",
+				filePath:       "",
+				extension:      "pdf",
+				createdAt:      time.Time{},
+				lastUpdated:    time.Time{},
+			},
+			expectedOutput: input_output{
+				id:             "", //Must have a new generated UUID
+				constructionID: testId,
+				title:          "Construction document",
+				filePath:       "", //Must generate a new filePath
+				extension:      "pdf",
+				createdAt:      time.Time{}, //Must have a CreatedAt with time.Now
+				lastUpdated:    time.Time{}, //Must have a LastUpdated with time.Now
+			},
+			expectedError: nil,
+		},
+		{
+			test: "Valid ConstructionDocument fields",
+			input: input_output{
+				id:             testId,
+				constructionID: testId,
+				title:          "Construction document",
+				filePath:       "path/to/file.pdf",
+				extension:      "pdf",
+				createdAt:      timeBefore,
+				lastUpdated:    timeNow,
+			},
+			expectedOutput: input_output{
+				id:             testId,
+				constructionID: testId,
+				title:          "Construction document",
+				filePath:       "path/to/file.pdf",
+				extension:      "pdf",
+				createdAt:      timeBefore,
+				lastUpdated:    timeNow,
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		constDoc, err := entity.NewDocument(
+			tc.input.id,
+			tc.input.constructionID,
+			tc.input.title,
+			tc.input.filePath,
+			tc.input.extension,
+			tc.input.lastUpdated,
+			tc.input.createdAt,
+		)
+
+		require.NotEmpty(t, constDoc.ID)
+
+		if tc.input.id != "" {
+			require.Equal(t, tc.expectedOutput.id, constDoc.ID)
+		}
+
+		require.Equal(t, tc.expectedOutput.constructionID, constDoc.ConstructionID)
+		require.Equal(t, tc.expectedOutput.title, constDoc.Title)
+
+		require.NotEmpty(t, constDoc.File.FilePath)
+		require.Equal(t, tc.expectedOutput.extension, constDoc.File.Extension)
+
+		require.NotZero(t, constDoc.CreatedAt)
+
+		if !tc.input.createdAt.IsZero() {
+			require.Equal(t, tc.expectedOutput.createdAt, constDoc.CreatedAt)
+		}
+
+		require.NotZero(t, constDoc.LastUpdated)
+
+		if !tc.input.createdAt.IsZero() {
+			require.Equal(t, tc.expectedOutput.lastUpdated, constDoc.LastUpdated)
+		}
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+}
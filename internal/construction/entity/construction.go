@@ -0,0 +1,185 @@
+package entity
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+)
+
+type ConstructionType string
+
+const (
+	Residential ConstructionType = "residential"
+	Commercial  ConstructionType = "commercial"
+)
+
+type ConstructionStatus string
+
+const (
+	StatusPlanning   ConstructionStatus = "planning"
+	StatusApproved   ConstructionStatus = "approved"
+	StatusInProgress ConstructionStatus = "in_progress"
+	StatusPaused     ConstructionStatus = "paused"
+	StatusDelivered  ConstructionStatus = "delivered"
+	StatusCancelled  ConstructionStatus = "cancelled"
+)
+
+var ErrInvalidStatusTransition = errors.New("construction status transition not allowed")
+
+var allowedStatusTransitions = map[ConstructionStatus][]ConstructionStatus{
+	StatusPlanning:   {StatusApproved, StatusCancelled},
+	StatusApproved:   {StatusInProgress, StatusCancelled},
+	StatusInProgress: {StatusPaused, StatusDelivered, StatusCancelled},
+	StatusPaused:     {StatusInProgress, StatusCancelled},
+	StatusDelivered:  {},
+	StatusCancelled:  {},
+}
+
+type Construction struct {
+	ID               string               `validate:"required,uuid"`
+	CompanyID        string               `validate:"required,uuid"`
+	Name             string               `validate:"required,min=3"`
+	Address          valueobjects.Address `validate:"required"`
+	LandRegistration string               `validate:"required"`
+	Type             ConstructionType     `validate:"required,oneof=residential commercial"`
+	Status           ConstructionStatus   `validate:"required,oneof=planning approved in_progress paused delivered cancelled"`
+	PlannedStartDate time.Time            `validate:"required"`
+	PlannedEndDate   time.Time            `validate:"required,gtfield=PlannedStartDate"`
+	ActualStartDate  time.Time            `validate:"required_if=Status in_progress,required_if=Status paused,required_if=Status delivered"`
+	ActualEndDate    time.Time            `validate:"required_if=Status delivered,omitempty,gtefield=ActualStartDate"`
+	StatusHistory    []StatusChange       `validate:"dive"`
+	CreatedAt        time.Time            `validate:"required"`
+}
+
+type StatusChange struct {
+	From ConstructionStatus `validate:"required"`
+	To   ConstructionStatus `validate:"required"`
+	At   time.Time          `validate:"required"`
+}
+
+func NewConstruction(id string, companyID string, name string, address valueobjects.Address, landRegistration string,
+	constructionType ConstructionType, plannedStartDate time.Time, plannedEndDate time.Time,
+	createdAt time.Time) (Construction, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	c := Construction{
+		ID:               id,
+		CompanyID:        companyID,
+		Name:             name,
+		Address:          address,
+		LandRegistration: landRegistration,
+		Type:             constructionType,
+		Status:           StatusPlanning,
+		PlannedStartDate: plannedStartDate,
+		PlannedEndDate:   plannedEndDate,
+		CreatedAt:        createdAt,
+	}
+
+	return c, validateConstruction(c)
+}
+
+func (c *Construction) Approve(at time.Time) error {
+	return c.transitionTo(StatusApproved, at)
+}
+
+// Start records the actual start date the first time the construction goes in progress.
+func (c *Construction) Start(at time.Time) error {
+	return c.transitionTo(StatusInProgress, at)
+}
+
+func (c *Construction) Pause(at time.Time) error {
+	return c.transitionTo(StatusPaused, at)
+}
+
+func (c *Construction) Resume(at time.Time) error {
+	return c.transitionTo(StatusInProgress, at)
+}
+
+func (c *Construction) Deliver(at time.Time) error {
+	return c.transitionTo(StatusDelivered, at)
+}
+
+func (c *Construction) Cancel(at time.Time) error {
+	return c.transitionTo(StatusCancelled, at)
+}
+
+// IsActive reports whether the construction still holds the company, that is, it was neither
+// delivered nor cancelled.
+func (c Construction) IsActive() bool {
+	return c.Status != StatusDelivered && c.Status != StatusCancelled
+}
+
+// CountActive counts the active constructions of a company, as required to close it.
+func CountActive(constructions []Construction, companyID string) int {
+	n := 0
+	for _, c := range constructions {
+		if c.CompanyID == companyID && c.IsActive() {
+			n++
+		}
+	}
+	return n
+}
+
+func (c Construction) CanTransitionTo(to ConstructionStatus) bool {
+	for _, allowed := range allowedStatusTransitions[c.Status] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Construction) transitionTo(to ConstructionStatus, at time.Time) error {
+	if !c.CanTransitionTo(to) {
+		return fmt.Errorf("%w: from %s to %s", ErrInvalidStatusTransition, c.Status, to)
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	updated := *c
+	updated.StatusHistory = append(append([]StatusChange(nil), c.StatusHistory...),
+		StatusChange{From: c.Status, To: to, At: at})
+	updated.Status = to
+
+	if to == StatusInProgress && updated.ActualStartDate.IsZero() {
+		updated.ActualStartDate = at
+	}
+
+	if to == StatusDelivered {
+		updated.ActualEndDate = at
+	}
+
+	if err := validateConstruction(updated); err != nil {
+		return err
+	}
+
+	*c = updated
+	return nil
+}
+
+func validateConstruction(c Construction) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(c)
+
+	return err
+}
+
+func ConstructionRootPath(constructionID string) string {
+	hash := md5.Sum([]byte(constructionID))
+	return "Construction-" + hex.EncodeToString(hash[:])
+}
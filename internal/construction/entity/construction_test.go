@@ -0,0 +1,162 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = valueobjects.Address{
+	Street:     "Rua Augusta",
+	Number:     "1500",
+	District:   "Consolação",
+	City:       "São Paulo",
+	State:      "SP",
+	PostalCode: "01304-001",
+}
+
+func TestConstruction_NewConstruction(t *testing.T) {
+	type input_output struct {
+		id               string
+		companyID        string
+		name             string
+		address          valueobjects.Address
+		landRegistration string
+		constructionType entity.ConstructionType
+		plannedStartDate time.Time
+		plannedEndDate   time.Time
+		createdAt        time.Time
+	}
+
+	type testCase struct {
+		test          string
+		input         input_output
+		expectedError error
+	}
+
+	testId := uuid.New().String()
+	timeNow := time.Now()
+	nextYear := timeNow.AddDate(1, 0, 0)
+
+	testsTable := []testCase{
+		{
+			test: "Empty CompanyID, Name, LandRegistration and Type error validation",
+			input: input_output{
+				id:               testId,
+				address:          testAddress,
+				plannedStartDate: timeNow,
+				plannedEndDate:   nextYear,
+				createdAt:        timeNow,
+			},
+			expectedError: errors.New("invalid fields: Construction.CompanyID: \"\", Construction.Name: \"\", " +
+				"Construction.LandRegistration: \"\", Construction.Type: \"\""),
+		},
+		{
+			test: "Planned end before planned start and invalid address error validation",
+			input: input_output{
+				id:               testId,
+				companyID:        testId,
+				name:             "Residencial Augusta",
+				address:          valueobjects.Address{Street: "Rua Augusta", State: "SP"},
+				landRegistration: "123.456",
+				constructionType: entity.Residential,
+				plannedStartDate: nextYear,
+				plannedEndDate:   timeNow,
+				createdAt:        timeNow,
+			},
+			expectedError: fmt.Errorf("invalid fields: Construction.Address.Number: \"\", Construction.Address.District: \"\", "+
+				"Construction.Address.City: \"\", Construction.Address.PostalCode: \"\", Construction.PlannedEndDate: \"%s\"", timeNow),
+		},
+		{
+			test: "Valid Construction fields generating new ID and CreatedAt when empty",
+			input: input_output{
+				companyID:        testId,
+				name:             "Residencial Augusta",
+				address:          testAddress,
+				landRegistration: "123.456",
+				constructionType: entity.Residential,
+				plannedStartDate: timeNow,
+				plannedEndDate:   nextYear,
+			},
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		c, err := entity.NewConstruction(
+			tc.input.id,
+			tc.input.companyID,
+			tc.input.name,
+			tc.input.address,
+			tc.input.landRegistration,
+			tc.input.constructionType,
+			tc.input.plannedStartDate,
+			tc.input.plannedEndDate,
+			tc.input.createdAt,
+		)
+
+		require.NotEmpty(t, c.ID)
+		require.NotZero(t, c.CreatedAt)
+		require.Equal(t, entity.StatusPlanning, c.Status)
+
+		if tc.input.id != "" {
+			require.Equal(t, tc.input.id, c.ID)
+		}
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+}
+
+func TestConstruction_Lifecycle(t *testing.T) {
+	start := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	c, err := entity.NewConstruction("", uuid.New().String(), "Residencial Augusta", testAddress, "123.456",
+		entity.Residential, start, start.AddDate(2, 0, 0), time.Time{})
+	require.Nil(t, err)
+
+	require.True(t, errors.Is(c.Start(start), entity.ErrInvalidStatusTransition))
+	require.Nil(t, c.Approve(start.AddDate(0, 0, -10)))
+	require.Nil(t, c.Start(start.AddDate(0, 0, 7)))
+	require.Nil(t, c.Pause(start.AddDate(0, 3, 0)))
+	require.Nil(t, c.Resume(start.AddDate(0, 4, 0)))
+	require.Equal(t, start.AddDate(0, 0, 7), c.ActualStartDate)
+	require.True(t, c.IsActive())
+
+	require.Nil(t, c.Deliver(start.AddDate(2, 1, 0)))
+	require.Equal(t, entity.StatusDelivered, c.Status)
+	require.Equal(t, start.AddDate(2, 1, 0), c.ActualEndDate)
+	require.False(t, c.IsActive())
+	require.Len(t, c.StatusHistory, 5)
+
+	require.True(t, errors.Is(c.Cancel(time.Time{}), entity.ErrInvalidStatusTransition))
+}
+
+func TestConstruction_CountActive(t *testing.T) {
+	companyID := uuid.New().String()
+	start := time.Now()
+
+	newConstruction := func(companyID string) entity.Construction {
+		c, err := entity.NewConstruction("", companyID, "Residencial Augusta", testAddress, "123.456",
+			entity.Residential, start, start.AddDate(2, 0, 0), time.Time{})
+		require.Nil(t, err)
+		return c
+	}
+
+	planning := newConstruction(companyID)
+	cancelled := newConstruction(companyID)
+	require.Nil(t, cancelled.Cancel(time.Time{}))
+	otherCompany := newConstruction(uuid.New().String())
+
+	require.Equal(t, 1, entity.CountActive([]entity.Construction{planning, cancelled, otherCompany}, companyID))
+}
@@ -0,0 +1,484 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	supplier "github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ContractStatus string
+
+const (
+	ContractDraft     ContractStatus = "draft"
+	ContractActive    ContractStatus = "active"
+	ContractDelivered ContractStatus = "delivered"
+)
+
+// DefaultRetentionPercent is the share of each measurement held until the work is delivered.
+func DefaultRetentionPercent() decimal.Decimal {
+	return decimal.NewFromInt(5)
+}
+
+var (
+	ErrContractNotDraft          = errors.New("contract is signed, change its scope with a change order")
+	ErrContractNotActive         = errors.New("contract is not active")
+	ErrContractEmpty             = errors.New("contract has no items")
+	ErrContractFromAnotherBudget = errors.New("contract refers to another budget")
+	ErrContractItemNotFound      = errors.New("contract item not found")
+	ErrMeasuredOverContract      = errors.New("measured quantity exceeds the contracted quantity")
+	ErrScopeBelowMeasured        = errors.New("change order reduces an item below its measured quantity")
+	ErrMeasurementPeriodOverlap  = errors.New("measurement period overlaps a previous measurement")
+	ErrContractNotCompleted      = errors.New("contract has a balance to complete")
+)
+
+// ContractItem is a service of the budget contracted at the price agreed with the supplier,
+// which may differ from the budgeted unit cost.
+type ContractItem struct {
+	ID           string          `validate:"required,uuid"`
+	BudgetItemID string          `validate:"required,uuid"`
+	Description  string          `validate:"required,min=2"`
+	Unit         string          `validate:"required,max=10"`
+	Quantity     decimal.Decimal `validate:"gt=0"`
+	UnitPrice    decimal.Decimal `validate:"gt=0"`
+}
+
+func (i ContractItem) Total() decimal.Decimal {
+	return i.Quantity.Mul(i.UnitPrice).Round(moneyPlaces)
+}
+
+// QuantityAdjustment adds to, or with a negative quantity suppresses from, a contracted item.
+type QuantityAdjustment struct {
+	ItemID   string          `validate:"required,uuid"`
+	Quantity decimal.Decimal `validate:"-"`
+}
+
+// ChangeOrder (aditivo) changes the scope of a signed contract with new items and quantity
+// adjustments of the existing ones.
+type ChangeOrder struct {
+	ID          string               `validate:"required,uuid"`
+	Number      int                  `validate:"gte=1"`
+	Description string               `validate:"required,min=3"`
+	Additions   []ContractItem       `validate:"dive"`
+	Adjustments []QuantityAdjustment `validate:"dive"`
+	ApprovedBy  string               `validate:"required"`
+	ApprovedAt  time.Time            `validate:"required"`
+}
+
+type ContractMeasurementLine struct {
+	ItemID   string          `validate:"required,uuid"`
+	Quantity decimal.Decimal `validate:"gt=0"`
+	Amount   decimal.Decimal `validate:"gte=0"`
+}
+
+// ContractMeasurement bills the quantities executed by the supplier in a period. Retention is
+// held from the gross amount and Net is what is paid for the measurement.
+type ContractMeasurement struct {
+	ID          string                    `validate:"required,uuid"`
+	Number      int                       `validate:"gte=1"`
+	PeriodStart time.Time                 `validate:"required"`
+	PeriodEnd   time.Time                 `validate:"required,gtefield=PeriodStart"`
+	Lines       []ContractMeasurementLine `validate:"required,min=1,dive"`
+	Gross       decimal.Decimal           `validate:"gte=0"`
+	Retention   decimal.Decimal           `validate:"gte=0"`
+	Net         decimal.Decimal           `validate:"gte=0"`
+	MeasuredAt  time.Time                 `validate:"required"`
+}
+
+// Contract is a subcontract of part of the budget of a construction. Its items are edited while
+// it is a draft, then changed only through change orders once signed.
+type Contract struct {
+	ID               string                `validate:"required,uuid"`
+	ConstructionID   string                `validate:"required,uuid"`
+	BudgetID         string                `validate:"required,uuid"`
+	SupplierID       string                `validate:"required,uuid"`
+	Number           string                `validate:"required"`
+	Description      string                `validate:"required,min=3"`
+	RetentionPercent decimal.Decimal       `validate:"gte=0,lte=100"`
+	Status           ContractStatus        `validate:"required,oneof=draft active delivered"`
+	Items            []ContractItem        `validate:"dive"`
+	ChangeOrders     []ChangeOrder         `validate:"dive"`
+	Measurements     []ContractMeasurement `validate:"dive"`
+	SignedAt         time.Time             `validate:"required_unless=Status draft"`
+	DeliveredAt      time.Time             `validate:"required_if=Status delivered"`
+	CreatedAt        time.Time             `validate:"required"`
+}
+
+// NewContract drafts a contract with a supplier over the approved budget of a construction.
+// Blocked suppliers cannot be contracted.
+func NewContract(id string, number string, budget Budget, s supplier.Supplier, description string,
+	createdAt time.Time) (Contract, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	if budget.Status != BudgetApproved {
+		return Contract{}, ErrBudgetNotApproved
+	}
+
+	if s.Blocked {
+		return Contract{}, fmt.Errorf("%w: %s", supplier.ErrSupplierBlocked, s.BlockReason)
+	}
+
+	c := Contract{
+		ID:               id,
+		ConstructionID:   budget.ConstructionID,
+		BudgetID:         budget.ID,
+		SupplierID:       s.ID,
+		Number:           number,
+		Description:      description,
+		RetentionPercent: DefaultRetentionPercent(),
+		Status:           ContractDraft,
+		CreatedAt:        createdAt,
+	}
+
+	return c, validateContract(c)
+}
+
+func (c *Contract) AddItem(budget Budget, budgetItemID string, quantity decimal.Decimal,
+	unitPrice decimal.Decimal) (ContractItem, error) {
+
+	if c.Status != ContractDraft {
+		return ContractItem{}, ErrContractNotDraft
+	}
+
+	item, err := c.newItem(budget, ContractItem{BudgetItemID: budgetItemID, Quantity: quantity, UnitPrice: unitPrice})
+	if err != nil {
+		return ContractItem{}, err
+	}
+
+	updated := c.clone()
+	updated.Items = append(updated.Items, item)
+
+	if err := c.update(updated); err != nil {
+		return ContractItem{}, err
+	}
+	return item, nil
+}
+
+func (c *Contract) Sign(at time.Time) error {
+	if c.Status != ContractDraft {
+		return ErrContractNotDraft
+	}
+
+	if len(c.Items) == 0 {
+		return ErrContractEmpty
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	updated := c.clone()
+	updated.Status = ContractActive
+	updated.SignedAt = at
+
+	return c.update(updated)
+}
+
+// AddChangeOrder approves a change order. Additions only need their budget item, quantity and
+// unit price; adjustments may not bring an item below the quantity already measured.
+func (c *Contract) AddChangeOrder(budget Budget, co ChangeOrder) (ChangeOrder, error) {
+	if c.Status != ContractActive {
+		return ChangeOrder{}, ErrContractNotActive
+	}
+
+	if co.ApprovedAt.IsZero() {
+		co.ApprovedAt = time.Now()
+	}
+
+	co.ID = uuid.New().String()
+	co.Number = len(c.ChangeOrders) + 1
+
+	additions := make([]ContractItem, 0, len(co.Additions))
+	for _, a := range co.Additions {
+		item, err := c.newItem(budget, a)
+		if err != nil {
+			return ChangeOrder{}, err
+		}
+		additions = append(additions, item)
+	}
+	co.Additions = additions
+
+	updated := c.clone()
+	updated.ChangeOrders = append(updated.ChangeOrders, co)
+
+	for _, adj := range co.Adjustments {
+		if _, ok := updated.Item(adj.ItemID); !ok {
+			return ChangeOrder{}, ErrContractItemNotFound
+		}
+
+		if updated.ItemQuantity(adj.ItemID).LessThan(updated.MeasuredQuantity(adj.ItemID)) {
+			return ChangeOrder{}, ErrScopeBelowMeasured
+		}
+	}
+
+	if err := c.update(updated); err != nil {
+		return ChangeOrder{}, err
+	}
+	return co, nil
+}
+
+// Measure bills the quantities executed in a period, which must start after the previous
+// measurement. The measurement that completes an item bills what is left of its value, so the
+// rounding of partial measurements never adds up to more or less than the item.
+func (c *Contract) Measure(periodStart time.Time, periodEnd time.Time, lines []ContractMeasurementLine,
+	measuredAt time.Time) (ContractMeasurement, error) {
+
+	if c.Status != ContractActive {
+		return ContractMeasurement{}, ErrContractNotActive
+	}
+
+	if measuredAt.IsZero() {
+		measuredAt = time.Now()
+	}
+
+	periodStart, periodEnd = truncateToDay(periodStart), truncateToDay(periodEnd)
+	if n := len(c.Measurements); n > 0 && !periodStart.After(c.Measurements[n-1].PeriodEnd) {
+		return ContractMeasurement{}, ErrMeasurementPeriodOverlap
+	}
+
+	m := ContractMeasurement{
+		ID:          uuid.New().String(),
+		Number:      len(c.Measurements) + 1,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		MeasuredAt:  measuredAt,
+	}
+
+	updated := c.clone()
+	updated.Measurements = append(updated.Measurements, m)
+	current := &updated.Measurements[len(updated.Measurements)-1]
+
+	for _, l := range lines {
+		item, ok := updated.Item(l.ItemID)
+		if !ok {
+			return ContractMeasurement{}, ErrContractItemNotFound
+		}
+
+		measuredBefore := updated.MeasuredQuantity(l.ItemID)
+		billedBefore := updated.MeasuredItemValue(l.ItemID)
+		contracted := updated.ItemQuantity(l.ItemID)
+
+		measured := measuredBefore.Add(l.Quantity)
+		if measured.GreaterThan(contracted) {
+			return ContractMeasurement{}, fmt.Errorf("%w: %s", ErrMeasuredOverContract, item.Description)
+		}
+
+		l.Amount = l.Quantity.Mul(item.UnitPrice).Round(moneyPlaces)
+		if measured.Equal(contracted) {
+			l.Amount = contracted.Mul(item.UnitPrice).Round(moneyPlaces).Sub(billedBefore)
+		}
+
+		current.Lines = append(current.Lines, l)
+		current.Gross = current.Gross.Add(l.Amount)
+	}
+
+	current.Retention = current.Gross.Mul(updated.RetentionPercent).Div(decimal.NewFromInt(100)).Round(moneyPlaces)
+	current.Net = current.Gross.Sub(current.Retention)
+
+	if err := c.update(updated); err != nil {
+		return ContractMeasurement{}, err
+	}
+	return *current, nil
+}
+
+// Deliver accepts the completed work and releases the retention held from the measurements,
+// returning the amount released.
+func (c *Contract) Deliver(at time.Time) (decimal.Decimal, error) {
+	if c.Status != ContractActive {
+		return decimal.Zero, ErrContractNotActive
+	}
+
+	if c.BalanceToComplete().IsPositive() {
+		return decimal.Zero, ErrContractNotCompleted
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	released := c.RetentionHeld()
+
+	updated := c.clone()
+	updated.Status = ContractDelivered
+	updated.DeliveredAt = at
+
+	if err := c.update(updated); err != nil {
+		return decimal.Zero, err
+	}
+	return released, nil
+}
+
+// Item looks up the contracted items, including the ones added by change orders.
+func (c Contract) Item(itemID string) (ContractItem, bool) {
+	for _, i := range c.allItems() {
+		if i.ID == itemID {
+			return i, true
+		}
+	}
+	return ContractItem{}, false
+}
+
+// ItemQuantity is the contracted quantity of an item after the change orders.
+func (c Contract) ItemQuantity(itemID string) decimal.Decimal {
+	item, ok := c.Item(itemID)
+	if !ok {
+		return decimal.Zero
+	}
+
+	quantity := item.Quantity
+	for _, co := range c.ChangeOrders {
+		for _, adj := range co.Adjustments {
+			if adj.ItemID == itemID {
+				quantity = quantity.Add(adj.Quantity)
+			}
+		}
+	}
+	return quantity
+}
+
+func (c Contract) MeasuredQuantity(itemID string) decimal.Decimal {
+	quantity := decimal.Zero
+	for _, m := range c.Measurements {
+		for _, l := range m.Lines {
+			if l.ItemID == itemID {
+				quantity = quantity.Add(l.Quantity)
+			}
+		}
+	}
+	return quantity
+}
+
+func (c Contract) MeasuredItemValue(itemID string) decimal.Decimal {
+	value := decimal.Zero
+	for _, m := range c.Measurements {
+		for _, l := range m.Lines {
+			if l.ItemID == itemID {
+				value = value.Add(l.Amount)
+			}
+		}
+	}
+	return value
+}
+
+// OriginalValue is the value of the contract as signed.
+func (c Contract) OriginalValue() decimal.Decimal {
+	total := decimal.Zero
+	for _, i := range c.Items {
+		total = total.Add(i.Total())
+	}
+	return total
+}
+
+// CurrentValue is the value of the contract after the change orders.
+func (c Contract) CurrentValue() decimal.Decimal {
+	total := decimal.Zero
+	for _, i := range c.allItems() {
+		total = total.Add(c.ItemQuantity(i.ID).Mul(i.UnitPrice).Round(moneyPlaces))
+	}
+	return total
+}
+
+// ChangeOrdersValue is how much the change orders added to, or suppressed from, the contract.
+func (c Contract) ChangeOrdersValue() decimal.Decimal {
+	return c.CurrentValue().Sub(c.OriginalValue())
+}
+
+func (c Contract) MeasuredValue() decimal.Decimal {
+	total := decimal.Zero
+	for _, m := range c.Measurements {
+		total = total.Add(m.Gross)
+	}
+	return total
+}
+
+// RetentionHeld is the retention of the measurements not yet released to the supplier.
+func (c Contract) RetentionHeld() decimal.Decimal {
+	if c.Status == ContractDelivered {
+		return decimal.Zero
+	}
+
+	total := decimal.Zero
+	for _, m := range c.Measurements {
+		total = total.Add(m.Retention)
+	}
+	return total
+}
+
+// BalanceToComplete is the value of the contract still to be measured. Items measured up to
+// their quantity have no balance, even when a change order cut them after partial measurements
+// whose rounding billed a cent more or less than their value.
+func (c Contract) BalanceToComplete() decimal.Decimal {
+	total := decimal.Zero
+	for _, i := range c.allItems() {
+		quantity := c.ItemQuantity(i.ID)
+		if !c.MeasuredQuantity(i.ID).LessThan(quantity) {
+			continue
+		}
+		total = total.Add(quantity.Mul(i.UnitPrice).Round(moneyPlaces).Sub(c.MeasuredItemValue(i.ID)))
+	}
+	return total
+}
+
+// newItem prices an item of the approved budget of the construction, which after a revision is
+// no longer the one the contract was drafted on.
+func (c Contract) newItem(budget Budget, item ContractItem) (ContractItem, error) {
+	if budget.ConstructionID != c.ConstructionID {
+		return ContractItem{}, ErrContractFromAnotherBudget
+	}
+
+	if budget.Status != BudgetApproved {
+		return ContractItem{}, ErrBudgetNotApproved
+	}
+
+	budgetItem, ok := budget.Item(item.BudgetItemID)
+	if !ok {
+		return ContractItem{}, ErrBudgetItemNotFound
+	}
+
+	item.ID = uuid.New().String()
+	item.Description = budgetItem.Description
+	item.Unit = budgetItem.Unit
+	item.UnitPrice = item.UnitPrice.Round(moneyPlaces)
+	return item, nil
+}
+
+func (c Contract) allItems() []ContractItem {
+	items := append([]ContractItem(nil), c.Items...)
+	for _, co := range c.ChangeOrders {
+		items = append(items, co.Additions...)
+	}
+	return items
+}
+
+func (c Contract) clone() Contract {
+	c.Items = append([]ContractItem(nil), c.Items...)
+	c.ChangeOrders = append([]ChangeOrder(nil), c.ChangeOrders...)
+	c.Measurements = append([]ContractMeasurement(nil), c.Measurements...)
+	return c
+}
+
+func (c *Contract) update(updated Contract) error {
+	if err := validateContract(updated); err != nil {
+		return err
+	}
+
+	*c = updated
+	return nil
+}
+
+func validateContract(c Contract) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(c)
+	return err
+}
@@ -0,0 +1,249 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	supplier "github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContract_NewContract(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Alvenaria")
+	require.Nil(t, err)
+	_, err = b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Alvenaria de vedação", Unit: "m2", Quantity: dec("1000"), UnitCost: dec("85"),
+	})
+	require.Nil(t, err)
+
+	s, err := supplier.NewSupplier("", "Construtora Tijolo Ltda", "", "11.222.333/0001-81",
+		[]supplier.TradeCategory{supplier.TradeMasonry}, time.Time{})
+	require.Nil(t, err)
+
+	_, err = entity.NewContract("", "CT-001", b, s, "Alvenaria das torres A e B", time.Time{})
+	require.Equal(t, entity.ErrBudgetNotApproved, err)
+
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+
+	superseded := b
+	require.Nil(t, superseded.Supersede())
+	_, err = entity.NewContract("", "CT-001", superseded, s, "Alvenaria das torres A e B", time.Time{})
+	require.Equal(t, entity.ErrBudgetNotApproved, err)
+
+	blocked := s
+	require.Nil(t, blocked.Block("Pending labor lawsuits", time.Time{}))
+	_, err = entity.NewContract("", "CT-001", b, blocked, "Alvenaria das torres A e B", time.Time{})
+	require.True(t, errors.Is(err, supplier.ErrSupplierBlocked))
+
+	_, err = entity.NewContract("", "", b, s, "Al", time.Time{})
+	require.Equal(t, errors.New("invalid fields: Contract.Number: \"\", Contract.Description: \"Al\""), err)
+
+	c, err := entity.NewContract("", "CT-001", b, s, "Alvenaria das torres A e B", time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, b.ConstructionID, c.ConstructionID)
+	require.Equal(t, s.ID, c.SupplierID)
+	require.Equal(t, entity.ContractDraft, c.Status)
+	require.True(t, entity.DefaultRetentionPercent().Equal(c.RetentionPercent))
+
+	require.Equal(t, entity.ErrContractEmpty, c.Sign(time.Time{}))
+}
+
+func TestContract_Lifecycle(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Alvenaria e revestimentos")
+	require.Nil(t, err)
+	alvenaria, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Alvenaria de vedação", Unit: "m2", Quantity: dec("1000"), UnitCost: dec("85"),
+	})
+	require.Nil(t, err)
+	reboco, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.2", Description: "Reboco interno", Unit: "m2", Quantity: dec("800"), UnitCost: dec("32"),
+	})
+	require.Nil(t, err)
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+
+	other, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	draft, err := entity.NewBudget("", b.ConstructionID, dec("20"), time.Time{})
+	require.Nil(t, err)
+
+	s, err := supplier.NewSupplier("", "Construtora Tijolo Ltda", "", "11.222.333/0001-81",
+		[]supplier.TradeCategory{supplier.TradeMasonry}, time.Time{})
+	require.Nil(t, err)
+
+	c, err := entity.NewContract("", "CT-001", b, s, "Alvenaria das torres A e B", time.Time{})
+	require.Nil(t, err)
+
+	_, err = c.AddItem(other, alvenaria.ID, dec("1000"), dec("42.35"))
+	require.Equal(t, entity.ErrContractFromAnotherBudget, err)
+	_, err = c.AddItem(draft, alvenaria.ID, dec("1000"), dec("42.35"))
+	require.Equal(t, entity.ErrBudgetNotApproved, err)
+	_, err = c.AddItem(b, uuid.New().String(), dec("1000"), dec("42.35"))
+	require.Equal(t, entity.ErrBudgetItemNotFound, err)
+
+	item, err := c.AddItem(b, alvenaria.ID, dec("1000"), dec("42.35"))
+	require.Nil(t, err)
+	require.Equal(t, "Alvenaria de vedação", item.Description)
+	require.True(t, dec("42350").Equal(c.OriginalValue()))
+
+	april := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	_, err = c.Measure(april, april.AddDate(0, 1, -1), []entity.ContractMeasurementLine{{ItemID: item.ID, Quantity: dec("10")}}, time.Time{})
+	require.Equal(t, entity.ErrContractNotActive, err)
+
+	require.Nil(t, c.Sign(april))
+	_, err = c.AddItem(b, reboco.ID, dec("800"), dec("25.10"))
+	require.Equal(t, entity.ErrContractNotDraft, err)
+
+	first, err := c.Measure(april, april.AddDate(0, 1, -1), []entity.ContractMeasurementLine{{ItemID: item.ID, Quantity: dec("333.3")}}, time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, 1, first.Number)
+	require.True(t, dec("14115.26").Equal(first.Gross), first.Gross.String())
+	require.True(t, dec("705.76").Equal(first.Retention), first.Retention.String())
+	require.True(t, dec("13409.50").Equal(first.Net), first.Net.String())
+
+	may := april.AddDate(0, 1, 0)
+	_, err = c.Measure(april.AddDate(0, 1, -1), may.AddDate(0, 1, -1), []entity.ContractMeasurementLine{{ItemID: item.ID, Quantity: dec("10")}}, time.Time{})
+	require.Equal(t, entity.ErrMeasurementPeriodOverlap, err)
+
+	_, err = c.Measure(may, may.AddDate(0, 1, -1), []entity.ContractMeasurementLine{{ItemID: item.ID, Quantity: dec("700")}}, time.Time{})
+	require.True(t, errors.Is(err, entity.ErrMeasuredOverContract))
+
+	_, err = c.Deliver(time.Time{})
+	require.Equal(t, entity.ErrContractNotCompleted, err)
+
+	_, err = c.AddChangeOrder(b, entity.ChangeOrder{
+		Description: "Supressão de alvenaria",
+		Adjustments: []entity.QuantityAdjustment{{ItemID: item.ID, Quantity: dec("-700")}},
+		ApprovedBy:  "engineer@lhs",
+	})
+	require.Equal(t, entity.ErrScopeBelowMeasured, err)
+
+	co, err := c.AddChangeOrder(b, entity.ChangeOrder{
+		Description: "Inclusão do reboco interno e supressão de alvenaria",
+		Additions:   []entity.ContractItem{{BudgetItemID: reboco.ID, Quantity: dec("800"), UnitPrice: dec("25.10")}},
+		Adjustments: []entity.QuantityAdjustment{{ItemID: item.ID, Quantity: dec("-100")}},
+		ApprovedBy:  "engineer@lhs",
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, co.Number)
+	require.True(t, dec("900").Equal(c.ItemQuantity(item.ID)))
+	require.True(t, dec("58195").Equal(c.CurrentValue()), c.CurrentValue().String())
+	require.True(t, dec("15845").Equal(c.ChangeOrdersValue()))
+	require.True(t, dec("44079.74").Equal(c.BalanceToComplete()), c.BalanceToComplete().String())
+
+	second, err := c.Measure(may, may.AddDate(0, 1, -1), []entity.ContractMeasurementLine{
+		{ItemID: item.ID, Quantity: dec("566.7")},
+		{ItemID: co.Additions[0].ID, Quantity: dec("800")},
+	}, time.Time{})
+	require.Nil(t, err)
+	require.True(t, dec("23999.74").Equal(second.Lines[0].Amount), second.Lines[0].Amount.String())
+	require.True(t, dec("44079.74").Equal(second.Gross), second.Gross.String())
+	require.True(t, dec("2203.99").Equal(second.Retention), second.Retention.String())
+	require.True(t, c.BalanceToComplete().IsZero())
+	require.True(t, dec("2909.75").Equal(c.RetentionHeld()))
+
+	released, err := c.Deliver(may.AddDate(0, 1, 0))
+	require.Nil(t, err)
+	require.True(t, dec("2909.75").Equal(released), released.String())
+	require.True(t, c.RetentionHeld().IsZero())
+	require.Equal(t, entity.ContractDelivered, c.Status)
+
+	_, err = c.AddChangeOrder(b, entity.ChangeOrder{Description: "Late change", ApprovedBy: "engineer@lhs"})
+	require.Equal(t, entity.ErrContractNotActive, err)
+}
+
+func TestContract_ChangeOrderFromRevision(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Alvenaria e revestimentos")
+	require.Nil(t, err)
+	alvenaria, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Alvenaria de vedação", Unit: "m2", Quantity: dec("1000"), UnitCost: dec("85"),
+	})
+	require.Nil(t, err)
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+
+	s, err := supplier.NewSupplier("", "Construtora Tijolo Ltda", "", "11.222.333/0001-81",
+		[]supplier.TradeCategory{supplier.TradeMasonry}, time.Time{})
+	require.Nil(t, err)
+
+	c, err := entity.NewContract("", "CT-001", b, s, "Alvenaria das torres A e B", time.Time{})
+	require.Nil(t, err)
+	_, err = c.AddItem(b, alvenaria.ID, dec("1000"), dec("42.35"))
+	require.Nil(t, err)
+	require.Nil(t, c.Sign(time.Time{}))
+
+	revision, err := b.NewRevision(time.Time{})
+	require.Nil(t, err)
+	reboco, err := revision.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.2", Description: "Reboco interno", Unit: "m2", Quantity: dec("800"), UnitCost: dec("32"),
+	})
+	require.Nil(t, err)
+	require.Nil(t, revision.Approve("engineer@lhs", time.Time{}))
+	require.Nil(t, b.Supersede())
+
+	addition := entity.ChangeOrder{
+		Description: "Inclusão do reboco interno",
+		Additions:   []entity.ContractItem{{BudgetItemID: reboco.ID, Quantity: dec("800"), UnitPrice: dec("25.10")}},
+		ApprovedBy:  "engineer@lhs",
+	}
+
+	_, err = c.AddChangeOrder(b, addition)
+	require.Equal(t, entity.ErrBudgetNotApproved, err)
+
+	co, err := c.AddChangeOrder(revision, addition)
+	require.Nil(t, err)
+	require.Equal(t, "Reboco interno", co.Additions[0].Description)
+	require.True(t, dec("20080").Equal(c.ChangeOrdersValue()), c.ChangeOrdersValue().String())
+}
+
+func TestContract_DeliverAfterSuppression(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Alvenaria")
+	require.Nil(t, err)
+	alvenaria, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Alvenaria de vedação", Unit: "m2", Quantity: dec("1000"), UnitCost: dec("85"),
+	})
+	require.Nil(t, err)
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+
+	s, err := supplier.NewSupplier("", "Construtora Tijolo Ltda", "", "11.222.333/0001-81",
+		[]supplier.TradeCategory{supplier.TradeMasonry}, time.Time{})
+	require.Nil(t, err)
+
+	c, err := entity.NewContract("", "CT-001", b, s, "Alvenaria das torres A e B", time.Time{})
+	require.Nil(t, err)
+	item, err := c.AddItem(b, alvenaria.ID, dec("1000"), dec("42.35"))
+	require.Nil(t, err)
+
+	april := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	may := april.AddDate(0, 1, 0)
+	require.Nil(t, c.Sign(april))
+
+	_, err = c.Measure(april, april.AddDate(0, 1, -1), []entity.ContractMeasurementLine{{ItemID: item.ID, Quantity: dec("333.3")}}, time.Time{})
+	require.Nil(t, err)
+	_, err = c.Measure(may, may.AddDate(0, 1, -1), []entity.ContractMeasurementLine{{ItemID: item.ID, Quantity: dec("333.3")}}, time.Time{})
+	require.Nil(t, err)
+	require.True(t, dec("28230.52").Equal(c.MeasuredValue()), c.MeasuredValue().String())
+
+	_, err = c.AddChangeOrder(b, entity.ChangeOrder{
+		Description: "Supressão da alvenaria da torre B",
+		Adjustments: []entity.QuantityAdjustment{{ItemID: item.ID, Quantity: dec("-333.4")}},
+		ApprovedBy:  "engineer@lhs",
+	})
+	require.Nil(t, err)
+	require.True(t, dec("28230.51").Equal(c.CurrentValue()), c.CurrentValue().String())
+	require.True(t, c.BalanceToComplete().IsZero(), c.BalanceToComplete().String())
+
+	released, err := c.Deliver(may.AddDate(0, 1, 0))
+	require.Nil(t, err)
+	require.True(t, dec("1411.52").Equal(released), released.String())
+	require.Equal(t, entity.ContractDelivered, c.Status)
+}
@@ -0,0 +1,241 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/calendar"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ContributionStatus string
+
+const (
+	ContributionPending ContributionStatus = "pending"
+	ContributionPaid    ContributionStatus = "paid"
+)
+
+var (
+	ErrContributionsOverCommitment = errors.New("scheduled contributions exceed the committed amount")
+	ErrContributionNotFound        = errors.New("contribution not found")
+	ErrContributionAlreadyPaid     = errors.New("contribution already paid")
+	ErrInvestmentsOverAllocated    = errors.New("investing partners hold more than 100% of the construction")
+	ErrDuplicatedInvestor          = errors.New("partner already invests in the construction")
+	ErrInvalidInstallments         = errors.New("number of installments must be at least one")
+	ErrNothingToSchedule           = errors.New("committed amount is already fully scheduled")
+)
+
+type Contribution struct {
+	ID      string             `validate:"required,uuid"`
+	Number  int                `validate:"gte=1"`
+	Amount  decimal.Decimal    `validate:"gt=0"`
+	DueDate time.Time          `validate:"required"`
+	Status  ContributionStatus `validate:"required,oneof=pending paid"`
+	PaidAt  time.Time          `validate:"required_if=Status paid"`
+}
+
+func (c Contribution) IsOverdue(at time.Time) bool {
+	return c.Status == ContributionPending && truncateToDay(at).After(c.DueDate)
+}
+
+// Investment is the participation of an existing partner in a construction: the amount the
+// partner committed, the share of the project and the plan of contributions to pay it.
+type Investment struct {
+	ID              string          `validate:"required,uuid"`
+	ConstructionID  string          `validate:"required,uuid"`
+	PartnerID       string          `validate:"required,uuid"`
+	CommittedAmount decimal.Decimal `validate:"gt=0"`
+	Percentage      decimal.Decimal `validate:"gt=0,lte=100"`
+	Contributions   []Contribution  `validate:"dive"`
+	CreatedAt       time.Time       `validate:"required"`
+}
+
+func NewInvestment(id string, constructionID string, partnerID string, committedAmount decimal.Decimal,
+	percentage decimal.Decimal, createdAt time.Time) (Investment, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	inv := Investment{
+		ID:              id,
+		ConstructionID:  constructionID,
+		PartnerID:       partnerID,
+		CommittedAmount: committedAmount.Round(moneyPlaces),
+		Percentage:      percentage,
+		CreatedAt:       createdAt,
+	}
+
+	return inv, validateInvestment(inv)
+}
+
+// AddContribution schedules a contribution. The contributions can never add up to more than
+// the committed amount.
+func (inv *Investment) AddContribution(amount decimal.Decimal, dueDate time.Time) (Contribution, error) {
+	c := Contribution{
+		ID:      uuid.New().String(),
+		Number:  len(inv.Contributions) + 1,
+		Amount:  amount.Round(moneyPlaces),
+		DueDate: truncateToDay(dueDate),
+		Status:  ContributionPending,
+	}
+
+	if inv.Scheduled().Add(c.Amount).GreaterThan(inv.CommittedAmount) {
+		return Contribution{}, ErrContributionsOverCommitment
+	}
+
+	updated := *inv
+	updated.Contributions = append(append([]Contribution(nil), inv.Contributions...), c)
+
+	if err := validateInvestment(updated); err != nil {
+		return Contribution{}, err
+	}
+
+	*inv = updated
+	return c, nil
+}
+
+// ScheduleInstallments splits what is left to schedule into monthly installments from
+// firstDueDate, on its day of the month or on the last day of shorter months. The cents left
+// by the division go to the last installment, and there are never more installments than
+// cents to schedule.
+func (inv *Investment) ScheduleInstallments(installments int, firstDueDate time.Time) ([]Contribution, error) {
+	if installments < 1 {
+		return nil, ErrInvalidInstallments
+	}
+
+	remaining := inv.Unscheduled()
+	if !remaining.IsPositive() {
+		return nil, ErrNothingToSchedule
+	}
+
+	if cents := remaining.Shift(moneyPlaces).IntPart(); int64(installments) > cents {
+		installments = int(cents)
+	}
+	amount := remaining.Div(decimal.NewFromInt(int64(installments))).RoundDown(moneyPlaces)
+
+	updated := *inv
+	var scheduled []Contribution
+	for i := 0; i < installments; i++ {
+		if i == installments-1 {
+			amount = remaining
+		}
+
+		c, err := updated.AddContribution(amount, calendar.AddMonths(firstDueDate, i))
+		if err != nil {
+			return nil, err
+		}
+
+		remaining = remaining.Sub(c.Amount)
+		scheduled = append(scheduled, c)
+	}
+
+	*inv = updated
+	return scheduled, nil
+}
+
+func (inv *Investment) PayContribution(contributionID string, paidAt time.Time) error {
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	updated := *inv
+	updated.Contributions = append([]Contribution(nil), inv.Contributions...)
+
+	for i := range updated.Contributions {
+		c := &updated.Contributions[i]
+		if c.ID != contributionID {
+			continue
+		}
+
+		if c.Status == ContributionPaid {
+			return ErrContributionAlreadyPaid
+		}
+
+		c.Status = ContributionPaid
+		c.PaidAt = paidAt
+
+		if err := validateInvestment(updated); err != nil {
+			return err
+		}
+
+		*inv = updated
+		return nil
+	}
+
+	return ErrContributionNotFound
+}
+
+func (inv Investment) Scheduled() decimal.Decimal {
+	total := decimal.Zero
+	for _, c := range inv.Contributions {
+		total = total.Add(c.Amount)
+	}
+	return total
+}
+
+// Unscheduled is the part of the commitment without a contribution yet.
+func (inv Investment) Unscheduled() decimal.Decimal {
+	return inv.CommittedAmount.Sub(inv.Scheduled())
+}
+
+func (inv Investment) Paid() decimal.Decimal {
+	total := decimal.Zero
+	for _, c := range inv.Contributions {
+		if c.Status == ContributionPaid {
+			total = total.Add(c.Amount)
+		}
+	}
+	return total
+}
+
+// Pending is what is left to contribute, scheduled or not.
+func (inv Investment) Pending() decimal.Decimal {
+	return inv.CommittedAmount.Sub(inv.Paid())
+}
+
+func (inv Investment) Overdue(at time.Time) []Contribution {
+	var overdue []Contribution
+	for _, c := range inv.Contributions {
+		if c.IsOverdue(at) {
+			overdue = append(overdue, c)
+		}
+	}
+	return overdue
+}
+
+// ValidateInvestors checks the investing partners of a construction as a whole: each partner
+// invests once and the shares add up to at most 100%.
+func ValidateInvestors(constructionID string, investments []Investment) error {
+	partners := make(map[string]bool)
+	total := decimal.Zero
+	for _, inv := range investments {
+		if inv.ConstructionID != constructionID {
+			continue
+		}
+
+		if partners[inv.PartnerID] {
+			return fmt.Errorf("%w: %s", ErrDuplicatedInvestor, inv.PartnerID)
+		}
+		partners[inv.PartnerID] = true
+		total = total.Add(inv.Percentage)
+	}
+
+	if total.GreaterThan(decimal.NewFromInt(100)) {
+		return ErrInvestmentsOverAllocated
+	}
+	return nil
+}
+
+func validateInvestment(inv Investment) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(inv)
+	return err
+}
@@ -0,0 +1,201 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvestment_NewInvestment(t *testing.T) {
+	type testCase struct {
+		test        string
+		committed   string
+		percentage  string
+		expectedErr string
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should require a committed amount",
+			committed:   "0",
+			percentage:  "10",
+			expectedErr: "invalid fields: Investment.CommittedAmount: \"0\"",
+		},
+		{
+			test:        "Should not hold more than the whole construction",
+			committed:   "100000",
+			percentage:  "100.01",
+			expectedErr: "invalid fields: Investment.Percentage: \"100.01\"",
+		},
+		{
+			test:       "Should create the investment",
+			committed:  "100000",
+			percentage: "25",
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		_, err := entity.NewInvestment("", uuid.New().String(), uuid.New().String(), dec(tc.committed),
+			dec(tc.percentage), time.Time{})
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+}
+
+func TestInvestment_Contributions(t *testing.T) {
+	inv, err := entity.NewInvestment("", uuid.New().String(), uuid.New().String(), dec("100000"), dec("25"), time.Time{})
+	require.Nil(t, err)
+
+	first := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	down, err := inv.AddContribution(dec("30000"), first)
+	require.Nil(t, err)
+
+	_, err = inv.AddContribution(dec("70000.01"), first)
+	require.Equal(t, entity.ErrContributionsOverCommitment, err)
+
+	_, err = inv.ScheduleInstallments(0, first)
+	require.Equal(t, entity.ErrInvalidInstallments, err)
+
+	installments, err := inv.ScheduleInstallments(3, first.AddDate(0, 1, 0))
+	require.Nil(t, err)
+	require.Len(t, installments, 3)
+	require.True(t, dec("23333.33").Equal(installments[0].Amount))
+	require.True(t, dec("23333.34").Equal(installments[2].Amount))
+	require.Equal(t, time.Date(2023, 4, 10, 0, 0, 0, 0, time.UTC), installments[2].DueDate)
+	require.Equal(t, 4, installments[2].Number)
+	require.True(t, inv.Unscheduled().IsZero())
+
+	require.Nil(t, inv.PayContribution(down.ID, first))
+	require.Equal(t, entity.ErrContributionAlreadyPaid, inv.PayContribution(down.ID, first))
+	require.Equal(t, entity.ErrContributionNotFound, inv.PayContribution(uuid.New().String(), first))
+
+	require.True(t, dec("30000").Equal(inv.Paid()))
+	require.True(t, dec("70000").Equal(inv.Pending()))
+	require.Len(t, inv.Overdue(time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)), 2)
+}
+
+func TestInvestment_ScheduleInstallmentsAtMonthEnd(t *testing.T) {
+	inv, err := entity.NewInvestment("", uuid.New().String(), uuid.New().String(), dec("90000"), dec("25"), time.Time{})
+	require.Nil(t, err)
+
+	installments, err := inv.ScheduleInstallments(3, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	require.Nil(t, err)
+	require.Len(t, installments, 3)
+	require.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), installments[0].DueDate)
+	require.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), installments[1].DueDate)
+	require.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), installments[2].DueDate)
+}
+
+func TestInvestment_ScheduleInstallmentsOfFewCents(t *testing.T) {
+	type testCase struct {
+		test         string
+		scheduled    string
+		installments int
+		expected     []string
+		expectedErr  error
+	}
+
+	testCases := []testCase{
+		{
+			test:         "Should reject a fully scheduled investment",
+			scheduled:    "100",
+			installments: 3,
+			expectedErr:  entity.ErrNothingToSchedule,
+		},
+		{
+			test:         "Should schedule no more installments than cents left",
+			scheduled:    "99.98",
+			installments: 3,
+			expected:     []string{"0.01", "0.01"},
+		},
+		{
+			test:         "Should schedule one installment per cent",
+			scheduled:    "99.97",
+			installments: 3,
+			expected:     []string{"0.01", "0.01", "0.01"},
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		inv, err := entity.NewInvestment("", uuid.New().String(), uuid.New().String(), dec("100"), dec("10"), time.Time{})
+		require.Nil(t, err)
+		_, err = inv.AddContribution(dec(tc.scheduled), time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC))
+		require.Nil(t, err)
+
+		installments, err := inv.ScheduleInstallments(tc.installments, time.Date(2023, 2, 10, 0, 0, 0, 0, time.UTC))
+		require.Equal(t, tc.expectedErr, err)
+		require.Len(t, installments, len(tc.expected))
+		for i, e := range tc.expected {
+			require.True(t, dec(e).Equal(installments[i].Amount), installments[i].Amount.String())
+		}
+	}
+}
+
+func TestInvestment_ValidateInvestors(t *testing.T) {
+	constructionID := uuid.New().String()
+	partnerID := uuid.New().String()
+
+	investment := func(constructionID string, partnerID string, percentage string) entity.Investment {
+		inv, err := entity.NewInvestment("", constructionID, partnerID, dec("1000"), dec(percentage), time.Time{})
+		require.Nil(t, err)
+		return inv
+	}
+
+	type testCase struct {
+		test        string
+		investments []entity.Investment
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test: "Should accept shares up to 100%, ignoring other constructions",
+			investments: []entity.Investment{
+				investment(constructionID, partnerID, "60"),
+				investment(constructionID, uuid.New().String(), "40"),
+				investment(uuid.New().String(), partnerID, "50"),
+			},
+		},
+		{
+			test: "Should reject shares over 100%",
+			investments: []entity.Investment{
+				investment(constructionID, partnerID, "60"),
+				investment(constructionID, uuid.New().String(), "40.5"),
+			},
+			expectedErr: entity.ErrInvestmentsOverAllocated,
+		},
+		{
+			test: "Should reject a partner investing twice",
+			investments: []entity.Investment{
+				investment(constructionID, partnerID, "10"),
+				investment(constructionID, partnerID, "10"),
+			},
+			expectedErr: entity.ErrDuplicatedInvestor,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		err := entity.ValidateInvestors(constructionID, tc.investments)
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr))
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+}
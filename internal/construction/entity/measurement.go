@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrMeasurementFromAnotherBudget = errors.New("measurement refers to another budget")
+	ErrNonPositiveQuantity          = errors.New("measured quantity must be positive")
+)
+
+type MeasuredItem struct {
+	BudgetItemID string          `validate:"required,uuid"`
+	Quantity     decimal.Decimal `validate:"gt=0"`
+}
+
+// Measurement (medição) records the quantity executed per budget item during a period,
+// measured against the approved budget of the construction.
+type Measurement struct {
+	ID             string         `validate:"required,uuid"`
+	ConstructionID string         `validate:"required,uuid"`
+	BudgetID       string         `validate:"required,uuid"`
+	Number         int            `validate:"gte=1"`
+	PeriodStart    time.Time      `validate:"required"`
+	PeriodEnd      time.Time      `validate:"required,gtefield=PeriodStart"`
+	Items          []MeasuredItem `validate:"dive"`
+	CreatedAt      time.Time      `validate:"required"`
+}
+
+func NewMeasurement(id string, constructionID string, budgetID string, number int, periodStart time.Time,
+	periodEnd time.Time, createdAt time.Time) (Measurement, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	m := Measurement{
+		ID:             id,
+		ConstructionID: constructionID,
+		BudgetID:       budgetID,
+		Number:         number,
+		PeriodStart:    truncateToDay(periodStart),
+		PeriodEnd:      truncateToDay(periodEnd),
+		CreatedAt:      createdAt,
+	}
+
+	return m, validateMeasurement(m)
+}
+
+// Measure records the quantity executed for an item of the approved budget. Measuring an item
+// again in the same period adds to its quantity; a measured quantity is never reduced.
+func (m *Measurement) Measure(budget Budget, itemID string, quantity decimal.Decimal) error {
+	if !quantity.IsPositive() {
+		return ErrNonPositiveQuantity
+	}
+
+	if budget.ID != m.BudgetID {
+		return ErrMeasurementFromAnotherBudget
+	}
+
+	if budget.Status == BudgetDraft {
+		return ErrBudgetNotApproved
+	}
+
+	if _, ok := budget.Item(itemID); !ok {
+		return ErrBudgetItemNotFound
+	}
+
+	updated := *m
+	updated.Items = append([]MeasuredItem(nil), m.Items...)
+
+	found := false
+	for i := range updated.Items {
+		if updated.Items[i].BudgetItemID == itemID {
+			updated.Items[i].Quantity = updated.Items[i].Quantity.Add(quantity)
+			found = true
+		}
+	}
+
+	if !found {
+		updated.Items = append(updated.Items, MeasuredItem{BudgetItemID: itemID, Quantity: quantity})
+	}
+
+	if err := validateMeasurement(updated); err != nil {
+		return err
+	}
+
+	*m = updated
+	return nil
+}
+
+func (m Measurement) QuantityOf(itemID string) decimal.Decimal {
+	for _, i := range m.Items {
+		if i.BudgetItemID == itemID {
+			return i.Quantity
+		}
+	}
+	return decimal.Zero
+}
+
+func validateMeasurement(m Measurement) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(m)
+	return err
+}
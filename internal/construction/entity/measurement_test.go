@@ -0,0 +1,105 @@
+package entity_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasurement_Measure(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Alvenaria")
+	require.Nil(t, err)
+	item, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Alvenaria de vedação", Unit: "m2", Quantity: dec("1000"), UnitCost: dec("85"),
+	})
+	require.Nil(t, err)
+
+	draft := b
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+
+	start := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	m, err := entity.NewMeasurement("", b.ConstructionID, b.ID, 1, start, start.AddDate(0, 1, -1), time.Time{})
+	require.Nil(t, err)
+
+	other, err := entity.NewBudget("", b.ConstructionID, dec("20"), time.Time{})
+	require.Nil(t, err)
+
+	type testCase struct {
+		test        string
+		budget      entity.Budget
+		itemID      string
+		quantity    string
+		expectedErr string
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should not measure against another budget",
+			budget:      other,
+			itemID:      item.ID,
+			quantity:    "10",
+			expectedErr: entity.ErrMeasurementFromAnotherBudget.Error(),
+		},
+		{
+			test:        "Should not measure against a draft budget",
+			budget:      draft,
+			itemID:      item.ID,
+			quantity:    "10",
+			expectedErr: entity.ErrBudgetNotApproved.Error(),
+		},
+		{
+			test:        "Should not measure an item outside the budget",
+			budget:      b,
+			itemID:      uuid.New().String(),
+			quantity:    "10",
+			expectedErr: entity.ErrBudgetItemNotFound.Error(),
+		},
+		{
+			test:        "Should require a positive quantity",
+			budget:      b,
+			itemID:      item.ID,
+			quantity:    "0",
+			expectedErr: entity.ErrNonPositiveQuantity.Error(),
+		},
+		{
+			test:     "Should measure the executed quantity",
+			budget:   b,
+			itemID:   item.ID,
+			quantity: "120.5",
+		},
+		{
+			test:     "Should add to the quantity already measured in the period",
+			budget:   b,
+			itemID:   item.ID,
+			quantity: "30",
+		},
+		{
+			test:        "Should not reduce the quantity already measured",
+			budget:      b,
+			itemID:      item.ID,
+			quantity:    "-10",
+			expectedErr: entity.ErrNonPositiveQuantity.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		err := m.Measure(tc.budget, tc.itemID, dec(tc.quantity))
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+
+	require.Len(t, m.Items, 1)
+	require.True(t, dec("150.5").Equal(m.QuantityOf(item.ID)))
+}
@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrUnitNotInPriceTable  = errors.New("unit not in the price table")
+	ErrWrongIndex           = errors.New("price table is adjusted by another index")
+	ErrPriceTableSuperseded = errors.New("price table is already superseded")
+	ErrPriceTableNotNewer   = errors.New("price table must have a later base month than the one it supersedes")
+)
+
+type UnitPrice struct {
+	UnitID string          `validate:"required,uuid"`
+	Price  decimal.Decimal `validate:"gte=0"`
+}
+
+// PriceTable fixes the unit prices at a base month. Prices in later months are the base prices
+// adjusted by the INCC, as construction costs rise until delivery. The table is in force from
+// its base month until SupersededAt, the base month of the table that replaced it.
+type PriceTable struct {
+	ID             string      `validate:"required,uuid"`
+	ConstructionID string      `validate:"required,uuid"`
+	BaseMonth      time.Time   `validate:"required"`
+	Index          index.Name  `validate:"required"`
+	Prices         []UnitPrice `validate:"required,min=1,dive"`
+	SupersededAt   time.Time   `validate:"omitempty,gtfield=BaseMonth"`
+	CreatedAt      time.Time   `validate:"required"`
+}
+
+// NewPriceTable takes the list prices of the available and reserved units of the construction.
+func NewPriceTable(id string, constructionID string, baseMonth time.Time, units []Unit,
+	createdAt time.Time) (PriceTable, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	pt := PriceTable{
+		ID:             id,
+		ConstructionID: constructionID,
+		BaseMonth:      time.Date(baseMonth.Year(), baseMonth.Month(), 1, 0, 0, 0, 0, time.UTC),
+		Index:          index.INCC,
+		CreatedAt:      createdAt,
+	}
+
+	for _, u := range units {
+		if u.ConstructionID == constructionID && (u.Status == UnitAvailable || u.Status == UnitReserved) {
+			pt.Prices = append(pt.Prices, UnitPrice{UnitID: u.ID, Price: u.ListPrice})
+		}
+	}
+
+	return pt, validatePriceTable(pt)
+}
+
+func (pt PriceTable) BasePrice(unitID string) (decimal.Decimal, error) {
+	for _, p := range pt.Prices {
+		if p.UnitID == unitID {
+			return p.Price, nil
+		}
+	}
+	return decimal.Zero, ErrUnitNotInPriceTable
+}
+
+// IsValidAt reports whether the table is in force on date, from its base month until it is
+// superseded.
+func (pt PriceTable) IsValidAt(date time.Time) bool {
+	return !date.Before(pt.BaseMonth) && (pt.SupersededAt.IsZero() || date.Before(pt.SupersededAt))
+}
+
+// Supersede retires the table from the base month of next, a later table of the same construction.
+func (pt *PriceTable) Supersede(next PriceTable) error {
+	if !pt.SupersededAt.IsZero() {
+		return ErrPriceTableSuperseded
+	}
+
+	if next.ConstructionID != pt.ConstructionID {
+		return ErrPriceTableOfAnotherConstruction
+	}
+
+	if !next.BaseMonth.After(pt.BaseMonth) {
+		return ErrPriceTableNotNewer
+	}
+
+	updated := *pt
+	updated.SupersededAt = next.BaseMonth
+
+	if err := validatePriceTable(updated); err != nil {
+		return err
+	}
+
+	*pt = updated
+	return nil
+}
+
+// PriceAt adjusts the base price of the unit up to the month of the date.
+func (pt PriceTable) PriceAt(unitID string, at time.Time, series *index.Series) (decimal.Decimal, error) {
+	if series.Name() != pt.Index {
+		return decimal.Zero, ErrWrongIndex
+	}
+
+	base, err := pt.BasePrice(unitID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return series.Correct(base, pt.BaseMonth, at)
+}
+
+func validatePriceTable(pt PriceTable) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(pt)
+	return err
+}
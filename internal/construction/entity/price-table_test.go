@@ -0,0 +1,66 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceTable_PriceAt(t *testing.T) {
+	constructionID := uuid.New().String()
+	available, err := entity.NewUnit("", constructionID, "101", entity.Apartment, dec("80"), dec("20"), dec("0.02"),
+		dec("500000"), time.Time{})
+	require.Nil(t, err)
+	swapped, err := entity.NewUnit("", constructionID, "102", entity.Apartment, dec("80"), dec("20"), dec("0.02"),
+		dec("500000"), time.Time{})
+	require.Nil(t, err)
+	require.Nil(t, swapped.Swap(uuid.New().String()))
+
+	_, err = entity.NewPriceTable("", constructionID, time.Now(), []entity.Unit{swapped}, time.Time{})
+	require.EqualError(t, err, "invalid fields: PriceTable.Prices: \"[]\"")
+
+	table, err := entity.NewPriceTable("", constructionID, time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC),
+		[]entity.Unit{available, swapped}, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, table.Prices, 1)
+
+	incc := index.NewSeries(index.INCC)
+	_, err = incc.Import([]index.Value{
+		{Date: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Percent: dec("0.5")},
+		{Date: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Percent: dec("0.4")},
+	}, "FGV IBRE", time.Time{})
+	require.Nil(t, err)
+
+	price, err := table.PriceAt(available.ID, time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC), incc)
+	require.Nil(t, err)
+	require.True(t, dec("504510").Equal(price), price.String())
+
+	_, err = table.PriceAt(swapped.ID, time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC), incc)
+	require.Equal(t, entity.ErrUnitNotInPriceTable, err)
+
+	_, err = table.PriceAt(available.ID, time.Date(2023, 4, 10, 0, 0, 0, 0, time.UTC), incc)
+	require.True(t, errors.Is(err, index.ErrMissingValue))
+
+	_, err = table.PriceAt(available.ID, time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC), index.NewSeries(index.IGPM))
+	require.Equal(t, entity.ErrWrongIndex, err)
+
+	next, err := entity.NewPriceTable("", constructionID, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		[]entity.Unit{available}, time.Time{})
+	require.Nil(t, err)
+	foreign := next
+	foreign.ConstructionID = uuid.New().String()
+
+	require.Equal(t, entity.ErrPriceTableOfAnotherConstruction, table.Supersede(foreign))
+	require.Equal(t, entity.ErrPriceTableNotNewer, next.Supersede(table))
+	require.Nil(t, table.Supersede(next))
+	require.Equal(t, entity.ErrPriceTableSuperseded, table.Supersede(next))
+
+	require.True(t, table.IsValidAt(time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)))
+	require.False(t, table.IsValidAt(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+	require.True(t, next.IsValidAt(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+}
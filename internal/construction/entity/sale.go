@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type SaleStatus string
+
+const (
+	SaleActive    SaleStatus = "active"
+	SaleCancelled SaleStatus = "cancelled"
+)
+
+var (
+	ErrSaleCancelled                   = errors.New("sale is cancelled")
+	ErrUnitOfAnotherSale               = errors.New("unit does not belong to the sale")
+	ErrUnitNotSold                     = errors.New("unit is not sold")
+	ErrPriceTableOfAnotherConstruction = errors.New("price table belongs to another construction")
+	ErrPriceTableNotValid              = errors.New("price table is not valid on the sale date")
+	ErrDiscountOverListPrice           = errors.New("discount must be lower than the list price")
+)
+
+type Buyer struct {
+	ID        string    `validate:"required,uuid"`
+	Name      string    `validate:"required,min=2"`
+	TaxID     string    `validate:"required,taxid"`
+	Email     string    `validate:"omitempty,email"`
+	Phone     string    `validate:"omitempty,e164"`
+	CreatedAt time.Time `validate:"required"`
+}
+
+func NewBuyer(id string, name string, taxID string, email string, phone string, createdAt time.Time) (Buyer, error) {
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	b := Buyer{ID: id, Name: name, TaxID: taxID, Email: email, Phone: phone, CreatedAt: createdAt}
+
+	return b, validateBuyer(b)
+}
+
+// Sale records the sale of a unit to a buyer, at the price of a price table, paid by a payment
+// plan. Price is the list price of the table on the sale date less the negotiated discount.
+type Sale struct {
+	ID             string          `validate:"required,uuid"`
+	ConstructionID string          `validate:"required,uuid"`
+	UnitID         string          `validate:"required,uuid"`
+	BuyerID        string          `validate:"required,uuid"`
+	PriceTableID   string          `validate:"omitempty,uuid"`
+	ListPrice      decimal.Decimal `validate:"gt=0"`
+	Discount       decimal.Decimal `validate:"gte=0"`
+	Price          decimal.Decimal `validate:"gt=0"`
+	PaymentPlanID  string          `validate:"omitempty,uuid"`
+	Status         SaleStatus      `validate:"required,oneof=active cancelled"`
+	SoldAt         time.Time       `validate:"required"`
+	CancelledAt    time.Time       `validate:"required_if=Status cancelled,omitempty,gtefield=SoldAt"`
+	CancelReason   string          `validate:"required_if=Status cancelled,omitempty,min=3"`
+	CreatedAt      time.Time       `validate:"required"`
+}
+
+// SellUnit sells a unit of the price table at its price adjusted by the index to the sale date,
+// less the negotiated discount. The table must be of the unit construction and in force on the
+// sale date. A unit reserved for another buyer can only be sold once the reservation expired.
+func SellUnit(unit *Unit, buyer Buyer, table PriceTable, series *index.Series, discount decimal.Decimal,
+	paymentPlanID string, soldAt time.Time, createdAt time.Time) (Sale, error) {
+
+	if soldAt.IsZero() {
+		soldAt = time.Now()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	if !unit.IsAvailableTo(buyer.ID, soldAt) {
+		return Sale{}, ErrUnitNotAvailable
+	}
+
+	if table.ConstructionID != unit.ConstructionID {
+		return Sale{}, ErrPriceTableOfAnotherConstruction
+	}
+
+	if !table.IsValidAt(soldAt) {
+		return Sale{}, ErrPriceTableNotValid
+	}
+
+	listPrice, err := table.PriceAt(unit.ID, soldAt, series)
+	if err != nil {
+		return Sale{}, err
+	}
+
+	discount = discount.Round(moneyPlaces)
+	if discount.GreaterThanOrEqual(listPrice) {
+		return Sale{}, ErrDiscountOverListPrice
+	}
+
+	s := Sale{
+		ID:             uuid.New().String(),
+		ConstructionID: unit.ConstructionID,
+		UnitID:         unit.ID,
+		BuyerID:        buyer.ID,
+		PriceTableID:   table.ID,
+		ListPrice:      listPrice,
+		Discount:       discount,
+		Price:          listPrice.Sub(discount),
+		PaymentPlanID:  paymentPlanID,
+		Status:         SaleActive,
+		SoldAt:         soldAt,
+		CreatedAt:      createdAt,
+	}
+
+	if err := validateSale(s); err != nil {
+		return Sale{}, err
+	}
+
+	if err := unit.update(func(updated *Unit) {
+		updated.Status = UnitSold
+		updated.Reservation = nil
+	}); err != nil {
+		return Sale{}, err
+	}
+
+	return s, nil
+}
+
+func (s *Sale) AttachPaymentPlan(paymentPlanID string) error {
+	if s.Status == SaleCancelled {
+		return ErrSaleCancelled
+	}
+
+	updated := *s
+	updated.PaymentPlanID = paymentPlanID
+
+	if err := validateSale(updated); err != nil {
+		return err
+	}
+
+	*s = updated
+	return nil
+}
+
+// Cancel undoes the sale (distrato) on a date not before the sale, for the reason agreed with
+// the buyer, and makes the unit available again.
+func (s *Sale) Cancel(unit *Unit, reason string, at time.Time) error {
+	if s.Status == SaleCancelled {
+		return ErrSaleCancelled
+	}
+
+	if unit.ID != s.UnitID || unit.ConstructionID != s.ConstructionID {
+		return ErrUnitOfAnotherSale
+	}
+
+	if unit.Status != UnitSold {
+		return ErrUnitNotSold
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	updated := *s
+	updated.Status = SaleCancelled
+	updated.CancelledAt = at
+	updated.CancelReason = reason
+
+	if err := validateSale(updated); err != nil {
+		return err
+	}
+
+	if err := unit.update(func(u *Unit) { u.Status = UnitAvailable }); err != nil {
+		return err
+	}
+
+	*s = updated
+	return nil
+}
+
+func validateBuyer(b Buyer) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(b)
+	return err
+}
+
+func validateSale(s Sale) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(s)
+	return err
+}
@@ -0,0 +1,143 @@
+package entity_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSale_SellUnit(t *testing.T) {
+	constructionID := uuid.New().String()
+	at := time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	newUnit := func(code string) entity.Unit {
+		u, err := entity.NewUnit("", constructionID, code, entity.Apartment, dec("80"), dec("20"), dec("0.02"),
+			dec("500000"), time.Time{})
+		require.Nil(t, err)
+		return u
+	}
+
+	buyer, err := entity.NewBuyer("", "Maria Silva", "529.982.247-25", "maria@example.com", "+5511987654321", time.Time{})
+	require.Nil(t, err)
+	other, err := entity.NewBuyer("", "João Souza", "111.444.777-35", "", "", time.Time{})
+	require.Nil(t, err)
+
+	available, reservedForOther, swapped, outOfTable := newUnit("101"), newUnit("102"), newUnit("103"), newUnit("104")
+	require.Nil(t, reservedForOther.Reserve(other.ID, at, at.AddDate(0, 0, 7)))
+
+	table, err := entity.NewPriceTable("", constructionID, at, []entity.Unit{available, reservedForOther, swapped},
+		time.Time{})
+	require.Nil(t, err)
+	require.Nil(t, swapped.Swap(uuid.New().String()))
+
+	incc := index.NewSeries(index.INCC)
+
+	type testCase struct {
+		test          string
+		unit          *entity.Unit
+		discount      string
+		expectedPrice string
+		expectedErr   string
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should not sell a unit reserved for another buyer",
+			unit:        &reservedForOther,
+			discount:    "0",
+			expectedErr: entity.ErrUnitNotAvailable.Error(),
+		},
+		{
+			test:        "Should not sell a swapped unit",
+			unit:        &swapped,
+			discount:    "0",
+			expectedErr: entity.ErrUnitNotAvailable.Error(),
+		},
+		{
+			test:        "Should not sell a unit outside the price table",
+			unit:        &outOfTable,
+			discount:    "0",
+			expectedErr: entity.ErrUnitNotInPriceTable.Error(),
+		},
+		{
+			test:        "Should not discount the whole list price",
+			unit:        &available,
+			discount:    "500000",
+			expectedErr: entity.ErrDiscountOverListPrice.Error(),
+		},
+		{
+			test:        "Should reject a negative discount",
+			unit:        &available,
+			discount:    "-1",
+			expectedErr: "invalid fields: Sale.Discount: \"-1\"",
+		},
+		{
+			test:          "Should sell an available unit at the list price less the discount",
+			unit:          &available,
+			discount:      "15000",
+			expectedPrice: "485000",
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		sale, err := entity.SellUnit(tc.unit, buyer, table, incc, dec(tc.discount), "", at, time.Time{})
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, entity.UnitSold, tc.unit.Status)
+		require.Equal(t, buyer.ID, sale.BuyerID)
+		require.Equal(t, table.ID, sale.PriceTableID)
+		require.True(t, dec("500000").Equal(sale.ListPrice))
+		require.True(t, dec(tc.expectedPrice).Equal(sale.Price), sale.Price.String())
+
+		require.Equal(t, entity.ErrUnitOfAnotherSale, sale.Cancel(&reservedForOther, "Distrato", at))
+		require.EqualError(t, sale.Cancel(tc.unit, "Distrato", at.AddDate(0, 0, -1)),
+			"invalid fields: Sale.CancelledAt: \""+at.AddDate(0, 0, -1).String()+"\"")
+		require.EqualError(t, sale.Cancel(tc.unit, "", at), "invalid fields: Sale.CancelReason: \"\"")
+		require.Equal(t, entity.SaleActive, sale.Status)
+		require.Equal(t, entity.UnitSold, tc.unit.Status)
+
+		notSold := *tc.unit
+		notSold.Status = entity.UnitAvailable
+		require.Equal(t, entity.ErrUnitNotSold, sale.Cancel(&notSold, "Distrato", at))
+
+		require.Nil(t, sale.Cancel(tc.unit, "Distrato a pedido do comprador", at.AddDate(0, 1, 0)))
+		require.Equal(t, entity.UnitAvailable, tc.unit.Status)
+		require.Equal(t, entity.ErrSaleCancelled, sale.Cancel(tc.unit, "Distrato", at))
+	}
+
+	_, err = entity.SellUnit(&available, buyer, table, incc, dec("0"), "", at.AddDate(0, -1, 0), time.Time{})
+	require.Equal(t, entity.ErrPriceTableNotValid, err)
+
+	superseded := table
+	next, err := entity.NewPriceTable("", constructionID, at.AddDate(0, 1, 0), []entity.Unit{available}, time.Time{})
+	require.Nil(t, err)
+	require.Nil(t, superseded.Supersede(next))
+	_, err = entity.SellUnit(&available, buyer, superseded, incc, dec("0"), "", at.AddDate(0, 1, 0), time.Time{})
+	require.Equal(t, entity.ErrPriceTableNotValid, err)
+
+	_, err = entity.SellUnit(&available, buyer, table, index.NewSeries(index.IGPM), dec("0"), "", at, time.Time{})
+	require.Equal(t, entity.ErrWrongIndex, err)
+
+	foreign, err := entity.NewUnit("", uuid.New().String(), "101", entity.Apartment, dec("80"), dec("20"), dec("0.02"),
+		dec("500000"), time.Time{})
+	require.Nil(t, err)
+	otherTable, err := entity.NewPriceTable("", foreign.ConstructionID, at, []entity.Unit{foreign}, time.Time{})
+	require.Nil(t, err)
+	_, err = entity.SellUnit(&available, buyer, otherTable, incc, dec("0"), "", at, time.Time{})
+	require.Equal(t, entity.ErrPriceTableOfAnotherConstruction, err)
+	require.Equal(t, entity.UnitAvailable, available.Status)
+
+	_, err = entity.NewBuyer("", "Maria Silva", "123", "", "", time.Time{})
+	require.EqualError(t, err, "invalid fields: Buyer.TaxID: \"123\"")
+}
@@ -0,0 +1,216 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+)
+
+type DependencyType string
+
+const (
+	FinishToStart DependencyType = "finish_to_start"
+	StartToStart  DependencyType = "start_to_start"
+)
+
+var (
+	ErrScheduleFromAnotherBudget = errors.New("schedule refers to another budget")
+	ErrTaskNotFound              = errors.New("schedule task not found")
+	ErrDuplicatedDependency      = errors.New("tasks are already linked")
+	ErrCyclicDependency          = errors.New("dependency would create a cycle")
+)
+
+// Task durations and lags are counted in workdays of the schedule calendar. A task without
+// duration is a milestone.
+type Task struct {
+	ID           string    `validate:"required,uuid"`
+	Code         string    `validate:"required"`
+	Name         string    `validate:"required,min=2"`
+	StageID      string    `validate:"required,uuid"`
+	Duration     int       `validate:"gte=0"`
+	ActualStart  time.Time `validate:"required_with=ActualFinish"`
+	ActualFinish time.Time `validate:"omitempty,gtefield=ActualStart"`
+}
+
+func (t Task) Started() bool {
+	return !t.ActualStart.IsZero()
+}
+
+func (t Task) Finished() bool {
+	return !t.ActualFinish.IsZero()
+}
+
+type Dependency struct {
+	PredecessorID string         `validate:"required,uuid"`
+	SuccessorID   string         `validate:"required,uuid,nefield=PredecessorID"`
+	Type          DependencyType `validate:"required,oneof=finish_to_start start_to_start"`
+	Lag           int            `validate:"-"`
+}
+
+// Schedule plans the construction tasks, each one linked to a stage of the budget.
+type Schedule struct {
+	ID             string       `validate:"required,uuid"`
+	ConstructionID string       `validate:"required,uuid"`
+	BudgetID       string       `validate:"required,uuid"`
+	StartDate      time.Time    `validate:"required"`
+	Tasks          []Task       `validate:"dive"`
+	Dependencies   []Dependency `validate:"dive"`
+	CreatedAt      time.Time    `validate:"required"`
+}
+
+func NewSchedule(id string, constructionID string, budgetID string, startDate time.Time,
+	createdAt time.Time) (Schedule, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	s := Schedule{
+		ID:             id,
+		ConstructionID: constructionID,
+		BudgetID:       budgetID,
+		StartDate:      truncateToDay(startDate),
+		CreatedAt:      createdAt,
+	}
+
+	return s, validateSchedule(s)
+}
+
+func (s *Schedule) AddTask(budget Budget, stageID string, code string, name string, duration int) (Task, error) {
+	if budget.ID != s.BudgetID {
+		return Task{}, ErrScheduleFromAnotherBudget
+	}
+
+	if _, ok := budget.Stage(stageID); !ok {
+		return Task{}, ErrStageNotFound
+	}
+
+	task := Task{ID: uuid.New().String(), Code: code, Name: name, StageID: stageID, Duration: duration}
+
+	updated := s.clone()
+	updated.Tasks = append(updated.Tasks, task)
+
+	if err := validateSchedule(updated); err != nil {
+		return Task{}, err
+	}
+
+	*s = updated
+	return task, nil
+}
+
+// Link makes successorID depend on predecessorID. A positive lag delays the successor and a
+// negative one lets it overlap the predecessor.
+func (s *Schedule) Link(predecessorID string, successorID string, depType DependencyType, lag int) error {
+	if _, ok := s.Task(predecessorID); !ok {
+		return ErrTaskNotFound
+	}
+
+	if _, ok := s.Task(successorID); !ok {
+		return ErrTaskNotFound
+	}
+
+	for _, d := range s.Dependencies {
+		if d.PredecessorID == predecessorID && d.SuccessorID == successorID {
+			return ErrDuplicatedDependency
+		}
+	}
+
+	if s.reaches(successorID, predecessorID) {
+		return ErrCyclicDependency
+	}
+
+	updated := s.clone()
+	updated.Dependencies = append(updated.Dependencies, Dependency{
+		PredecessorID: predecessorID,
+		SuccessorID:   successorID,
+		Type:          depType,
+		Lag:           lag,
+	})
+
+	if err := validateSchedule(updated); err != nil {
+		return err
+	}
+
+	*s = updated
+	return nil
+}
+
+// SetActualDates records when a task really started and finished. finish may be zero while
+// the task is in progress.
+func (s *Schedule) SetActualDates(taskID string, start time.Time, finish time.Time) error {
+	updated := s.clone()
+
+	var task *Task
+	for i := range updated.Tasks {
+		if updated.Tasks[i].ID == taskID {
+			task = &updated.Tasks[i]
+		}
+	}
+
+	if task == nil {
+		return ErrTaskNotFound
+	}
+
+	task.ActualStart = truncateToDay(start)
+	task.ActualFinish = truncateToDay(finish)
+
+	if err := validateSchedule(updated); err != nil {
+		return err
+	}
+
+	*s = updated
+	return nil
+}
+
+func (s Schedule) Task(taskID string) (Task, bool) {
+	for _, t := range s.Tasks {
+		if t.ID == taskID {
+			return t, true
+		}
+	}
+	return Task{}, false
+}
+
+// reaches tells whether to can be reached from from following the dependencies.
+func (s Schedule) reaches(from string, to string) bool {
+	visited := make(map[string]bool)
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if id == to {
+			return true
+		}
+
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		for _, d := range s.Dependencies {
+			if d.PredecessorID == id {
+				stack = append(stack, d.SuccessorID)
+			}
+		}
+	}
+	return false
+}
+
+func (s Schedule) clone() Schedule {
+	s.Tasks = append([]Task(nil), s.Tasks...)
+	s.Dependencies = append([]Dependency(nil), s.Dependencies...)
+	return s
+}
+
+func validateSchedule(s Schedule) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(s)
+	return err
+}
@@ -0,0 +1,118 @@
+package entity_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Link(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("20"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Estrutura")
+	require.Nil(t, err)
+
+	s, err := entity.NewSchedule("", b.ConstructionID, b.ID, time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), time.Time{})
+	require.Nil(t, err)
+
+	_, err = s.AddTask(b, uuid.New().String(), "1", "Fundação", 5)
+	require.Equal(t, entity.ErrStageNotFound, err)
+
+	other, err := entity.NewBudget("", b.ConstructionID, dec("20"), time.Time{})
+	require.Nil(t, err)
+	_, err = s.AddTask(other, stage.ID, "1", "Fundação", 5)
+	require.Equal(t, entity.ErrScheduleFromAnotherBudget, err)
+
+	_, err = s.AddTask(b, stage.ID, "1", "Fundação", -1)
+	require.EqualError(t, err, "invalid fields: Schedule.Tasks[0].Duration: \"-1\"")
+
+	a, err := s.AddTask(b, stage.ID, "1", "Fundação", 5)
+	require.Nil(t, err)
+	c, err := s.AddTask(b, stage.ID, "2", "Estrutura", 10)
+	require.Nil(t, err)
+	d, err := s.AddTask(b, stage.ID, "3", "Alvenaria", 8)
+	require.Nil(t, err)
+
+	type testCase struct {
+		test        string
+		predecessor string
+		successor   string
+		depType     entity.DependencyType
+		expectedErr string
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should link a finish to start dependency",
+			predecessor: a.ID,
+			successor:   c.ID,
+			depType:     entity.FinishToStart,
+		},
+		{
+			test:        "Should link a start to start dependency",
+			predecessor: c.ID,
+			successor:   d.ID,
+			depType:     entity.StartToStart,
+		},
+		{
+			test:        "Should not link the same tasks twice",
+			predecessor: a.ID,
+			successor:   c.ID,
+			depType:     entity.StartToStart,
+			expectedErr: entity.ErrDuplicatedDependency.Error(),
+		},
+		{
+			test:        "Should not create a cycle",
+			predecessor: d.ID,
+			successor:   a.ID,
+			depType:     entity.FinishToStart,
+			expectedErr: entity.ErrCyclicDependency.Error(),
+		},
+		{
+			test:        "Should not link a task to itself",
+			predecessor: a.ID,
+			successor:   a.ID,
+			depType:     entity.FinishToStart,
+			expectedErr: entity.ErrCyclicDependency.Error(),
+		},
+		{
+			test:        "Should not link unknown tasks",
+			predecessor: a.ID,
+			successor:   uuid.New().String(),
+			depType:     entity.FinishToStart,
+			expectedErr: entity.ErrTaskNotFound.Error(),
+		},
+		{
+			test:        "Should reject unknown dependency types",
+			predecessor: a.ID,
+			successor:   d.ID,
+			depType:     "finish_to_finish",
+			expectedErr: "invalid fields: Schedule.Dependencies[2].Type: \"finish_to_finish\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		err := s.Link(tc.predecessor, tc.successor, tc.depType, 0)
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+
+	require.Len(t, s.Dependencies, 2)
+
+	require.EqualError(t, s.SetActualDates(a.ID, time.Time{}, time.Date(2023, 4, 10, 0, 0, 0, 0, time.UTC)),
+		"invalid fields: Schedule.Tasks[0].ActualStart: \"0001-01-01 00:00:00 +0000 UTC\"")
+	require.Nil(t, s.SetActualDates(a.ID, time.Date(2023, 4, 4, 9, 0, 0, 0, time.UTC), time.Time{}))
+	task, _ := s.Task(a.ID)
+	require.True(t, task.Started())
+	require.False(t, task.Finished())
+}
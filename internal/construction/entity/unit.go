@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type UnitType string
+
+const (
+	Apartment      UnitType = "apartment"
+	ParkingSpace   UnitType = "parking_space"
+	Storage        UnitType = "storage"
+	CommercialRoom UnitType = "commercial_room"
+)
+
+type UnitStatus string
+
+const (
+	UnitAvailable UnitStatus = "available"
+	UnitReserved  UnitStatus = "reserved"
+	UnitSold      UnitStatus = "sold"
+	UnitSwapped   UnitStatus = "swapped"
+)
+
+var (
+	ErrUnitNotAvailable        = errors.New("unit is not available")
+	ErrUnitNotReserved         = errors.New("unit is not reserved")
+	ErrReservedForAnotherBuyer = errors.New("unit is reserved for another buyer")
+	ErrLandFractionsOverWhole  = errors.New("land fractions of the units add up to more than the whole land")
+	ErrDuplicatedUnitCode      = errors.New("unit code already used in the construction")
+)
+
+// Reservation holds a unit for a buyer until the sale is signed or the reservation expires.
+type Reservation struct {
+	BuyerID    string    `validate:"required,uuid"`
+	ReservedAt time.Time `validate:"required"`
+	ExpiresAt  time.Time `validate:"required,gtfield=ReservedAt"`
+}
+
+// Unit is a real estate unit produced by a construction. LandFraction is the fração ideal of
+// the land, as a ratio of the whole. Swapped units are given to the landowner in exchange for
+// the land (permuta) and are never sold.
+type Unit struct {
+	ID             string          `validate:"required,uuid"`
+	ConstructionID string          `validate:"required,uuid"`
+	Code           string          `validate:"required"`
+	Type           UnitType        `validate:"required,oneof=apartment parking_space storage commercial_room"`
+	PrivateArea    decimal.Decimal `validate:"gt=0"`
+	CommonArea     decimal.Decimal `validate:"gte=0"`
+	LandFraction   decimal.Decimal `validate:"gt=0,lte=1"`
+	ListPrice      decimal.Decimal `validate:"gte=0"`
+	Status         UnitStatus      `validate:"required,oneof=available reserved sold swapped"`
+	Reservation    *Reservation    `validate:"required_if=Status reserved,omitempty"`
+	LandownerID    string          `validate:"required_if=Status swapped,omitempty,uuid"`
+	CreatedAt      time.Time       `validate:"required"`
+}
+
+func NewUnit(id string, constructionID string, code string, unitType UnitType, privateArea decimal.Decimal,
+	commonArea decimal.Decimal, landFraction decimal.Decimal, listPrice decimal.Decimal, createdAt time.Time) (Unit, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	u := Unit{
+		ID:             id,
+		ConstructionID: constructionID,
+		Code:           code,
+		Type:           unitType,
+		PrivateArea:    privateArea,
+		CommonArea:     commonArea,
+		LandFraction:   landFraction,
+		ListPrice:      listPrice.Round(moneyPlaces),
+		Status:         UnitAvailable,
+		CreatedAt:      createdAt,
+	}
+
+	return u, validateUnit(u)
+}
+
+func (u Unit) TotalArea() decimal.Decimal {
+	return u.PrivateArea.Add(u.CommonArea)
+}
+
+// IsAvailableTo tells whether the unit can be sold to the buyer on the date: it is available,
+// or reserved for the buyer, or its reservation expired.
+func (u Unit) IsAvailableTo(buyerID string, at time.Time) bool {
+	switch u.Status {
+	case UnitAvailable:
+		return true
+	case UnitReserved:
+		return u.Reservation.BuyerID == buyerID || at.After(u.Reservation.ExpiresAt)
+	default:
+		return false
+	}
+}
+
+func (u *Unit) Reserve(buyerID string, at time.Time, expiresAt time.Time) error {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if u.Status == UnitReserved && u.Reservation.BuyerID != buyerID && !at.After(u.Reservation.ExpiresAt) {
+		return ErrReservedForAnotherBuyer
+	}
+
+	if u.Status != UnitAvailable && u.Status != UnitReserved {
+		return ErrUnitNotAvailable
+	}
+
+	return u.update(func(updated *Unit) {
+		updated.Status = UnitReserved
+		updated.Reservation = &Reservation{BuyerID: buyerID, ReservedAt: at, ExpiresAt: expiresAt}
+	})
+}
+
+func (u *Unit) ReleaseReservation() error {
+	if u.Status != UnitReserved {
+		return ErrUnitNotReserved
+	}
+
+	return u.update(func(updated *Unit) {
+		updated.Status = UnitAvailable
+		updated.Reservation = nil
+	})
+}
+
+// Swap gives the unit to the landowner as payment for the land.
+func (u *Unit) Swap(landownerID string) error {
+	if u.Status != UnitAvailable {
+		return ErrUnitNotAvailable
+	}
+
+	return u.update(func(updated *Unit) {
+		updated.Status = UnitSwapped
+		updated.LandownerID = landownerID
+	})
+}
+
+func (u *Unit) update(change func(updated *Unit)) error {
+	updated := *u
+	change(&updated)
+
+	if err := validateUnit(updated); err != nil {
+		return err
+	}
+
+	*u = updated
+	return nil
+}
+
+// ValidateUnits checks the units of a construction as a whole: codes are unique and the land
+// fractions add up to at most the whole land.
+func ValidateUnits(constructionID string, units []Unit) error {
+	codes := make(map[string]bool)
+	total := decimal.Zero
+	for _, u := range units {
+		if u.ConstructionID != constructionID {
+			continue
+		}
+
+		if codes[u.Code] {
+			return ErrDuplicatedUnitCode
+		}
+		codes[u.Code] = true
+		total = total.Add(u.LandFraction)
+	}
+
+	if total.GreaterThan(decimal.NewFromInt(1)) {
+		return ErrLandFractionsOverWhole
+	}
+	return nil
+}
+
+func validateUnit(u Unit) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(u)
+	return err
+}
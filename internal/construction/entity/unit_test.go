@@ -0,0 +1,107 @@
+package entity_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_NewUnit(t *testing.T) {
+	type testCase struct {
+		test         string
+		unitType     entity.UnitType
+		privateArea  string
+		landFraction string
+		expectedErr  string
+	}
+
+	testCases := []testCase{
+		{
+			test:         "Should reject an unknown unit type",
+			unitType:     "penthouse",
+			privateArea:  "120",
+			landFraction: "0.0125",
+			expectedErr:  "invalid fields: Unit.Type: \"penthouse\"",
+		},
+		{
+			test:         "Should require the private area",
+			unitType:     entity.Apartment,
+			privateArea:  "0",
+			landFraction: "0.0125",
+			expectedErr:  "invalid fields: Unit.PrivateArea: \"0\"",
+		},
+		{
+			test:         "Should not have a land fraction over the whole land",
+			unitType:     entity.Apartment,
+			privateArea:  "120",
+			landFraction: "1.5",
+			expectedErr:  "invalid fields: Unit.LandFraction: \"1.5\"",
+		},
+		{
+			test:         "Should create an available unit",
+			unitType:     entity.ParkingSpace,
+			privateArea:  "12.5",
+			landFraction: "0.0021",
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		u, err := entity.NewUnit("", uuid.New().String(), "101", tc.unitType, dec(tc.privateArea), dec("30"),
+			dec(tc.landFraction), dec("650000"), time.Time{})
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, entity.UnitAvailable, u.Status)
+		require.True(t, dec("42.5").Equal(u.TotalArea()))
+	}
+}
+
+func TestUnit_Reserve(t *testing.T) {
+	u, err := entity.NewUnit("", uuid.New().String(), "101", entity.Apartment, dec("80"), dec("20"), dec("0.02"),
+		dec("650000"), time.Time{})
+	require.Nil(t, err)
+
+	buyer, other := uuid.New().String(), uuid.New().String()
+	at := time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, entity.ErrUnitNotReserved, u.ReleaseReservation())
+	require.EqualError(t, u.Reserve(buyer, at, at.AddDate(0, 0, -1)),
+		fmt.Sprintf("invalid fields: Unit.Reservation.ExpiresAt: \"%s\"", at.AddDate(0, 0, -1)))
+	require.Nil(t, u.Reserve(buyer, at, at.AddDate(0, 0, 7)))
+	require.Equal(t, entity.ErrReservedForAnotherBuyer, u.Reserve(other, at.AddDate(0, 0, 3), at.AddDate(0, 0, 10)))
+	require.False(t, u.IsAvailableTo(other, at.AddDate(0, 0, 3)))
+	require.True(t, u.IsAvailableTo(other, at.AddDate(0, 0, 8)))
+	require.Nil(t, u.Reserve(other, at.AddDate(0, 0, 8), at.AddDate(0, 0, 15)))
+	require.Equal(t, other, u.Reservation.BuyerID)
+
+	require.Equal(t, entity.ErrUnitNotAvailable, u.Swap(uuid.New().String()))
+	require.Nil(t, u.ReleaseReservation())
+	require.Nil(t, u.Swap(uuid.New().String()))
+	require.Equal(t, entity.UnitSwapped, u.Status)
+	require.Equal(t, entity.ErrUnitNotAvailable, u.Reserve(buyer, at, at.AddDate(0, 0, 7)))
+}
+
+func TestUnit_ValidateUnits(t *testing.T) {
+	constructionID := uuid.New().String()
+	unit := func(code string, fraction string) entity.Unit {
+		u, err := entity.NewUnit("", constructionID, code, entity.Apartment, dec("80"), dec("20"), dec(fraction),
+			dec("650000"), time.Time{})
+		require.Nil(t, err)
+		return u
+	}
+
+	require.Nil(t, entity.ValidateUnits(constructionID, []entity.Unit{unit("101", "0.5"), unit("102", "0.5")}))
+	require.Equal(t, entity.ErrLandFractionsOverWhole,
+		entity.ValidateUnits(constructionID, []entity.Unit{unit("101", "0.5"), unit("102", "0.5001")}))
+	require.Equal(t, entity.ErrDuplicatedUnitCode,
+		entity.ValidateUnits(constructionID, []entity.Unit{unit("101", "0.1"), unit("101", "0.1")}))
+}
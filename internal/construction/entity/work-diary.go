@@ -0,0 +1,274 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+)
+
+type DayPeriod string
+
+const (
+	Morning   DayPeriod = "morning"
+	Afternoon DayPeriod = "afternoon"
+	Night     DayPeriod = "night"
+)
+
+type WeatherCondition string
+
+const (
+	WeatherClear     WeatherCondition = "clear"
+	WeatherCloudy    WeatherCondition = "cloudy"
+	WeatherLightRain WeatherCondition = "light_rain"
+	WeatherHeavyRain WeatherCondition = "heavy_rain"
+)
+
+// ActivityProgress tells what happened to a schedule task that day, so the schedule can take
+// its actual dates from the diary.
+type ActivityProgress string
+
+const (
+	ActivityStarted            ActivityProgress = "started"
+	ActivityOngoing            ActivityProgress = "ongoing"
+	ActivityFinished           ActivityProgress = "finished"
+	ActivityStartedAndFinished ActivityProgress = "started_and_finished"
+)
+
+type IncidentSeverity string
+
+const (
+	SeverityLow    IncidentSeverity = "low"
+	SeverityMedium IncidentSeverity = "medium"
+	SeverityHigh   IncidentSeverity = "high"
+)
+
+var (
+	ErrDiaryEntrySigned                = errors.New("work diary entry is signed and cannot be edited, append a note instead")
+	ErrDiaryEntryNotSigned             = errors.New("notes can only be appended to signed work diary entries")
+	ErrDuplicatedWeatherPeriod         = errors.New("weather already recorded for the period")
+	ErrDocumentFromAnotherConstruction = errors.New("document belongs to another construction")
+	ErrSignatureBeforeDiaryDate        = errors.New("work diary entry cannot be signed before its date")
+)
+
+type Weather struct {
+	Period    DayPeriod        `validate:"required,oneof=morning afternoon night"`
+	Condition WeatherCondition `validate:"required,oneof=clear cloudy light_rain heavy_rain"`
+	Workable  bool             `validate:"-"`
+}
+
+type Workforce struct {
+	Trade      string `validate:"required,min=2"`
+	Headcount  int    `validate:"gte=1"`
+	Contractor string `validate:"-"`
+}
+
+type Equipment struct {
+	Name     string `validate:"required,min=2"`
+	Quantity int    `validate:"gte=1"`
+}
+
+type DiaryActivity struct {
+	Description string           `validate:"required,min=3"`
+	TaskID      string           `validate:"omitempty,uuid"`
+	Progress    ActivityProgress `validate:"required_with=TaskID,omitempty,oneof=started ongoing finished started_and_finished"`
+}
+
+type Incident struct {
+	Description string           `validate:"required,min=3"`
+	Severity    IncidentSeverity `validate:"required,oneof=low medium high"`
+}
+
+type Visitor struct {
+	Name         string `validate:"required,min=2"`
+	Organization string `validate:"-"`
+	Purpose      string `validate:"required"`
+}
+
+// DiaryNote is an addition made after the entry was signed. The entry itself is never changed.
+type DiaryNote struct {
+	Author    string    `validate:"required"`
+	Text      string    `validate:"required,min=3"`
+	PhotoIDs  []string  `validate:"dive,uuid"`
+	CreatedAt time.Time `validate:"required"`
+}
+
+// WorkDiaryEntry is the daily record of a construction (Diário de Obra). It is locked once the
+// engineer signs it.
+type WorkDiaryEntry struct {
+	ID             string          `validate:"required,uuid"`
+	ConstructionID string          `validate:"required,uuid"`
+	Date           time.Time       `validate:"required"`
+	Weather        []Weather       `validate:"dive"`
+	Workforce      []Workforce     `validate:"dive"`
+	Equipment      []Equipment     `validate:"dive"`
+	Activities     []DiaryActivity `validate:"dive"`
+	Incidents      []Incident      `validate:"dive"`
+	Visitors       []Visitor       `validate:"dive"`
+	PhotoIDs       []string        `validate:"dive,uuid"`
+	SignedBy       string          `validate:"required_with=SignedAt"`
+	SignedAt       time.Time       `validate:"required_with=SignedBy"`
+	Notes          []DiaryNote     `validate:"dive"`
+	CreatedAt      time.Time       `validate:"required"`
+}
+
+func NewWorkDiaryEntry(id string, constructionID string, date time.Time, createdAt time.Time) (WorkDiaryEntry, error) {
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	e := WorkDiaryEntry{
+		ID:             id,
+		ConstructionID: constructionID,
+		Date:           truncateToDay(date),
+		CreatedAt:      createdAt,
+	}
+
+	return e, validateWorkDiaryEntry(e)
+}
+
+func (e WorkDiaryEntry) IsSigned() bool {
+	return !e.SignedAt.IsZero()
+}
+
+func (e *WorkDiaryEntry) RecordWeather(period DayPeriod, condition WeatherCondition, workable bool) error {
+	for _, w := range e.Weather {
+		if w.Period == period {
+			return ErrDuplicatedWeatherPeriod
+		}
+	}
+
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.Weather = append(u.Weather, Weather{Period: period, Condition: condition, Workable: workable})
+	})
+}
+
+func (e *WorkDiaryEntry) AddWorkforce(trade string, headcount int, contractor string) error {
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.Workforce = append(u.Workforce, Workforce{Trade: trade, Headcount: headcount, Contractor: contractor})
+	})
+}
+
+func (e *WorkDiaryEntry) AddEquipment(name string, quantity int) error {
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.Equipment = append(u.Equipment, Equipment{Name: name, Quantity: quantity})
+	})
+}
+
+// AddActivity records a service performed. taskID and progress are optional and link the
+// activity to a schedule task.
+func (e *WorkDiaryEntry) AddActivity(description string, taskID string, progress ActivityProgress) error {
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.Activities = append(u.Activities, DiaryActivity{Description: description, TaskID: taskID, Progress: progress})
+	})
+}
+
+func (e *WorkDiaryEntry) AddIncident(description string, severity IncidentSeverity) error {
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.Incidents = append(u.Incidents, Incident{Description: description, Severity: severity})
+	})
+}
+
+func (e *WorkDiaryEntry) AddVisitor(name string, organization string, purpose string) error {
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.Visitors = append(u.Visitors, Visitor{Name: name, Organization: organization, Purpose: purpose})
+	})
+}
+
+func (e *WorkDiaryEntry) AttachPhoto(photo ConstructionDocument) error {
+	if photo.ConstructionID != e.ConstructionID {
+		return ErrDocumentFromAnotherConstruction
+	}
+
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.PhotoIDs = append(u.PhotoIDs, photo.ID)
+	})
+}
+
+// Sign locks the entry. Anything learned afterwards goes into an appended note.
+func (e *WorkDiaryEntry) Sign(engineer string, at time.Time) error {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if at.Before(e.Date) {
+		return ErrSignatureBeforeDiaryDate
+	}
+
+	return e.edit(func(u *WorkDiaryEntry) {
+		u.SignedBy = engineer
+		u.SignedAt = at
+	})
+}
+
+func (e *WorkDiaryEntry) AppendNote(author string, text string, photos []ConstructionDocument, at time.Time) error {
+	if !e.IsSigned() {
+		return ErrDiaryEntryNotSigned
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	note := DiaryNote{Author: author, Text: text, CreatedAt: at}
+	for _, photo := range photos {
+		if photo.ConstructionID != e.ConstructionID {
+			return ErrDocumentFromAnotherConstruction
+		}
+		note.PhotoIDs = append(note.PhotoIDs, photo.ID)
+	}
+
+	updated := e.clone()
+	updated.Notes = append(updated.Notes, note)
+
+	if err := validateWorkDiaryEntry(updated); err != nil {
+		return err
+	}
+
+	*e = updated
+	return nil
+}
+
+func (e *WorkDiaryEntry) edit(change func(u *WorkDiaryEntry)) error {
+	if e.IsSigned() {
+		return ErrDiaryEntrySigned
+	}
+
+	updated := e.clone()
+	change(&updated)
+
+	if err := validateWorkDiaryEntry(updated); err != nil {
+		return err
+	}
+
+	*e = updated
+	return nil
+}
+
+func (e WorkDiaryEntry) clone() WorkDiaryEntry {
+	e.Weather = append([]Weather(nil), e.Weather...)
+	e.Workforce = append([]Workforce(nil), e.Workforce...)
+	e.Equipment = append([]Equipment(nil), e.Equipment...)
+	e.Activities = append([]DiaryActivity(nil), e.Activities...)
+	e.Incidents = append([]Incident(nil), e.Incidents...)
+	e.Visitors = append([]Visitor(nil), e.Visitors...)
+	e.PhotoIDs = append([]string(nil), e.PhotoIDs...)
+	e.Notes = append([]DiaryNote(nil), e.Notes...)
+	return e
+}
+
+func truncateToDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func validateWorkDiaryEntry(e WorkDiaryEntry) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(e)
+	return err
+}
@@ -0,0 +1,135 @@
+package entity_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkDiaryEntry_Record(t *testing.T) {
+	constructionID := uuid.New().String()
+	date := time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC)
+
+	e, err := entity.NewWorkDiaryEntry("", constructionID, date.Add(15*time.Hour), time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, date, e.Date)
+
+	photo, err := entity.NewDocument("", constructionID, "Concretagem laje 3", "", "jpg", time.Time{}, time.Time{})
+	require.Nil(t, err)
+	otherPhoto, err := entity.NewDocument("", uuid.New().String(), "Fachada", "", "jpg", time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	type testCase struct {
+		test        string
+		record      func() error
+		expectedErr string
+	}
+
+	testCases := []testCase{
+		{
+			test:   "Should record the weather of the morning",
+			record: func() error { return e.RecordWeather(entity.Morning, entity.WeatherClear, true) },
+		},
+		{
+			test:        "Should not record the weather twice for the same period",
+			record:      func() error { return e.RecordWeather(entity.Morning, entity.WeatherCloudy, true) },
+			expectedErr: entity.ErrDuplicatedWeatherPeriod.Error(),
+		},
+		{
+			test:        "Should reject an unknown weather condition",
+			record:      func() error { return e.RecordWeather(entity.Afternoon, "snow", false) },
+			expectedErr: "invalid fields: WorkDiaryEntry.Weather[1].Condition: \"snow\"",
+		},
+		{
+			test:   "Should record the workforce by trade",
+			record: func() error { return e.AddWorkforce("Pedreiro", 6, "Empreiteira Silva") },
+		},
+		{
+			test:        "Should require at least one worker",
+			record:      func() error { return e.AddWorkforce("Armador", 0, "") },
+			expectedErr: "invalid fields: WorkDiaryEntry.Workforce[1].Headcount: \"0\"",
+		},
+		{
+			test:   "Should record the equipment",
+			record: func() error { return e.AddEquipment("Betoneira 400L", 1) },
+		},
+		{
+			test: "Should record an activity linked to a schedule task",
+			record: func() error {
+				return e.AddActivity("Concretagem da laje do 3º pavimento", uuid.New().String(), entity.ActivityStarted)
+			},
+		},
+		{
+			test: "Should require the progress of an activity linked to a task",
+			record: func() error {
+				return e.AddActivity("Alvenaria", uuid.New().String(), "")
+			},
+			expectedErr: "invalid fields: WorkDiaryEntry.Activities[1].Progress: \"\"",
+		},
+		{
+			test:   "Should record an incident",
+			record: func() error { return e.AddIncident("Queda de material sem feridos", entity.SeverityLow) },
+		},
+		{
+			test:   "Should record a visitor",
+			record: func() error { return e.AddVisitor("João Souza", "Prefeitura", "Fiscalização") },
+		},
+		{
+			test:   "Should attach a photo of the construction",
+			record: func() error { return e.AttachPhoto(photo) },
+		},
+		{
+			test:        "Should not attach a photo of another construction",
+			record:      func() error { return e.AttachPhoto(otherPhoto) },
+			expectedErr: entity.ErrDocumentFromAnotherConstruction.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		err := tc.record()
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+
+	require.Len(t, e.Weather, 1)
+	require.Len(t, e.Workforce, 1)
+	require.Len(t, e.Activities, 1)
+	require.Equal(t, []string{photo.ID}, e.PhotoIDs)
+}
+
+func TestWorkDiaryEntry_Sign(t *testing.T) {
+	constructionID := uuid.New().String()
+	date := time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC)
+
+	e, err := entity.NewWorkDiaryEntry("", constructionID, date, time.Time{})
+	require.Nil(t, err)
+	require.Nil(t, e.AddWorkforce("Pedreiro", 6, ""))
+
+	require.Equal(t, entity.ErrDiaryEntryNotSigned, e.AppendNote("eng. Maria", "Chuva à noite", nil, time.Time{}))
+	require.Equal(t, entity.ErrSignatureBeforeDiaryDate, e.Sign("eng. Maria", date.Add(-time.Hour)))
+	require.Nil(t, e.Sign("eng. Maria", date.Add(18*time.Hour)))
+	require.True(t, e.IsSigned())
+
+	require.Equal(t, entity.ErrDiaryEntrySigned, e.AddWorkforce("Servente", 2, ""))
+	require.Equal(t, entity.ErrDiaryEntrySigned, e.RecordWeather(entity.Night, entity.WeatherHeavyRain, false))
+	require.Len(t, e.Workforce, 1)
+
+	photo, err := entity.NewDocument("", constructionID, "Alagamento do subsolo", "", "jpg", time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	require.Nil(t, e.AppendNote("eng. Maria", "Chuva forte à noite alagou o subsolo", []entity.ConstructionDocument{photo},
+		date.AddDate(0, 0, 1)))
+	require.Len(t, e.Notes, 1)
+	require.Equal(t, []string{photo.ID}, e.Notes[0].PhotoIDs)
+	require.Empty(t, e.PhotoIDs)
+}
@@ -0,0 +1,168 @@
+package progress
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrMeasuredOverBudget                 = errors.New("measured quantity exceeds the budgeted quantity")
+	ErrDuplicatedMeasurement              = errors.New("measurement number already used")
+	ErrInvalidPlannedPercent              = errors.New("planned progress must grow from 0 to 100 percent")
+	ErrMeasurementFromAnotherConstruction = errors.New("measurement refers to another construction")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Point is the cumulative progress of the construction at the end of a period. Physical is the
+// percent of the budget direct cost executed and Value the executed amount with BDI.
+type Point struct {
+	Period   time.Time
+	Physical decimal.Decimal
+	Value    decimal.Decimal
+}
+
+type ItemProgress struct {
+	BudgetItemID string
+	Budgeted     decimal.Decimal
+	Executed     decimal.Decimal
+	Percent      decimal.Decimal
+}
+
+type Progress struct {
+	BudgetID string
+	Points   []Point
+	Items    []ItemProgress
+}
+
+// Current is the progress after the last measurement.
+func (p Progress) Current() Point {
+	if len(p.Points) == 0 {
+		return Point{Physical: decimal.Zero, Value: decimal.Zero}
+	}
+	return p.Points[len(p.Points)-1]
+}
+
+// Compute accumulates the measurements of a construction in measurement number order. Items are
+// weighted by their share of the budget direct cost. Measurements taken against earlier revisions
+// of the budget count as well, as revisions keep the IDs of their items.
+func Compute(budget entity.Budget, measurements []entity.Measurement) (Progress, error) {
+	sorted, err := sortMeasurements(budget, measurements)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	executed := make(map[string]decimal.Decimal)
+	directCost := budget.DirectCost()
+	executedCost := decimal.Zero
+
+	p := Progress{BudgetID: budget.ID}
+	for _, m := range sorted {
+		for _, mi := range m.Items {
+			item, ok := budget.Item(mi.BudgetItemID)
+			if !ok {
+				return Progress{}, fmt.Errorf("%w: %s", entity.ErrBudgetItemNotFound, mi.BudgetItemID)
+			}
+
+			total := executed[item.ID].Add(mi.Quantity)
+			if total.GreaterThan(item.Quantity) {
+				return Progress{}, fmt.Errorf("%w: item %s in measurement %d", ErrMeasuredOverBudget, item.Code, m.Number)
+			}
+			executed[item.ID] = total
+
+			executedCost = executedCost.Add(mi.Quantity.Mul(item.UnitCostWithoutBDI()))
+		}
+
+		p.Points = append(p.Points, Point{
+			Period:   m.PeriodEnd,
+			Physical: percent(executedCost, directCost),
+			Value:    budget.WithBDI(executedCost),
+		})
+	}
+
+	for _, item := range budget.Items() {
+		p.Items = append(p.Items, ItemProgress{
+			BudgetItemID: item.ID,
+			Budgeted:     item.Quantity,
+			Executed:     executed[item.ID],
+			Percent:      percent(executed[item.ID], item.Quantity),
+		})
+	}
+
+	return p, nil
+}
+
+// PlannedPoint is the cumulative percent of the budget planned to be executed by the end of a
+// period, as taken from the schedule.
+type PlannedPoint struct {
+	Period  time.Time
+	Percent decimal.Decimal
+}
+
+// SCurve holds the planned and actual cumulative series, ready to be plotted.
+type SCurve struct {
+	Planned []Point
+	Actual  []Point
+}
+
+func BuildSCurve(budget entity.Budget, planned []PlannedPoint, measurements []entity.Measurement) (SCurve, error) {
+	plan := append([]PlannedPoint(nil), planned...)
+	sort.Slice(plan, func(i, j int) bool { return plan[i].Period.Before(plan[j].Period) })
+
+	last := decimal.Zero
+	for _, pp := range plan {
+		if pp.Percent.LessThan(last) || pp.Percent.GreaterThan(hundred) {
+			return SCurve{}, ErrInvalidPlannedPercent
+		}
+		last = pp.Percent
+	}
+
+	actual, err := Compute(budget, measurements)
+	if err != nil {
+		return SCurve{}, err
+	}
+
+	curve := SCurve{Actual: actual.Points}
+	total := budget.Total()
+	for _, pp := range plan {
+		curve.Planned = append(curve.Planned, Point{
+			Period:   pp.Period,
+			Physical: pp.Percent,
+			Value:    total.Mul(pp.Percent).Div(hundred).Round(2),
+		})
+	}
+
+	return curve, nil
+}
+
+func sortMeasurements(budget entity.Budget, measurements []entity.Measurement) ([]entity.Measurement, error) {
+	sorted := make([]entity.Measurement, 0, len(measurements))
+	numbers := make(map[int]bool)
+	for _, m := range measurements {
+		if m.ConstructionID != budget.ConstructionID {
+			return nil, ErrMeasurementFromAnotherConstruction
+		}
+
+		if numbers[m.Number] {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicatedMeasurement, m.Number)
+		}
+		numbers[m.Number] = true
+
+		sorted = append(sorted, m)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+	return sorted, nil
+}
+
+func percent(part decimal.Decimal, whole decimal.Decimal) decimal.Decimal {
+	if !whole.IsPositive() {
+		return decimal.Zero
+	}
+	return part.Mul(hundred).Div(whole).Round(2)
+}
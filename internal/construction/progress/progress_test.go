@@ -0,0 +1,130 @@
+package progress_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/progress"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestProgress_BuildSCurve(t *testing.T) {
+	b, err := entity.NewBudget("", uuid.New().String(), dec("25"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Obra")
+	require.Nil(t, err)
+	masonry, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Alvenaria", Unit: "m2", Quantity: dec("1000"), UnitCost: dec("60"),
+	})
+	require.Nil(t, err)
+	painting, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.2", Description: "Pintura", Unit: "m2", Quantity: dec("2000"), UnitCost: dec("20"),
+	})
+	require.Nil(t, err)
+	require.Nil(t, b.Approve("engineer@lhs", time.Time{}))
+
+	month := func(m time.Month) time.Time { return time.Date(2023, m, 1, 0, 0, 0, 0, time.UTC) }
+
+	measurement := func(number int, m time.Month, quantities map[string]string) entity.Measurement {
+		ms, err := entity.NewMeasurement("", b.ConstructionID, b.ID, number, month(m), month(m+1).AddDate(0, 0, -1),
+			time.Time{})
+		require.Nil(t, err)
+		for itemID, q := range quantities {
+			require.Nil(t, ms.Measure(b, itemID, dec(q)))
+		}
+		return ms
+	}
+
+	first := measurement(1, time.April, map[string]string{masonry.ID: "500"})
+	second := measurement(2, time.May, map[string]string{masonry.ID: "500", painting.ID: "500"})
+
+	planned := []progress.PlannedPoint{
+		{Period: month(time.May), Percent: dec("60")},
+		{Period: month(time.April), Percent: dec("25")},
+		{Period: month(time.June), Percent: dec("100")},
+	}
+
+	type testCase struct {
+		test         string
+		planned      []progress.PlannedPoint
+		measurements []entity.Measurement
+		expectedErr  error
+	}
+
+	testCases := []testCase{
+		{
+			test:         "Should reject a planned curve that decreases",
+			planned:      []progress.PlannedPoint{{Period: month(time.April), Percent: dec("50")}, {Period: month(time.May), Percent: dec("40")}},
+			measurements: []entity.Measurement{first},
+			expectedErr:  progress.ErrInvalidPlannedPercent,
+		},
+		{
+			test:         "Should reject measurements over the budgeted quantity",
+			planned:      planned,
+			measurements: []entity.Measurement{first, second, measurement(3, time.June, map[string]string{masonry.ID: "1"})},
+			expectedErr:  progress.ErrMeasuredOverBudget,
+		},
+		{
+			test:         "Should reject a measurement number used twice",
+			planned:      planned,
+			measurements: []entity.Measurement{first, first},
+			expectedErr:  progress.ErrDuplicatedMeasurement,
+		},
+		{
+			test:         "Should build the planned and actual series",
+			planned:      planned,
+			measurements: []entity.Measurement{second, first},
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		curve, err := progress.BuildSCurve(b, tc.planned, tc.measurements)
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr))
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Len(t, curve.Planned, 3)
+		require.Equal(t, month(time.April), curve.Planned[0].Period)
+		require.True(t, dec("31250").Equal(curve.Planned[0].Value))
+
+		require.Len(t, curve.Actual, 2)
+		require.True(t, dec("30").Equal(curve.Actual[0].Physical))
+		require.True(t, dec("37500").Equal(curve.Actual[0].Value))
+		require.True(t, dec("70").Equal(curve.Actual[1].Physical))
+		require.True(t, dec("87500").Equal(curve.Actual[1].Value))
+	}
+
+	p, err := progress.Compute(b, []entity.Measurement{first, second})
+	require.Nil(t, err)
+	require.True(t, dec("70").Equal(p.Current().Physical))
+	require.True(t, dec("100").Equal(p.Items[0].Percent))
+	require.True(t, dec("25").Equal(p.Items[1].Percent))
+
+	revision, err := b.NewRevision(time.Time{})
+	require.Nil(t, err)
+	require.Nil(t, revision.Approve("engineer@lhs", time.Time{}))
+
+	p, err = progress.Compute(revision, []entity.Measurement{first, second})
+	require.Nil(t, err)
+	require.Equal(t, revision.ID, p.BudgetID)
+	require.True(t, dec("70").Equal(p.Current().Physical))
+
+	other, err := entity.NewMeasurement("", uuid.New().String(), b.ID, 3, month(time.June), month(time.July).AddDate(0, 0, -1),
+		time.Time{})
+	require.Nil(t, err)
+	_, err = progress.Compute(b, []entity.Measurement{first, other})
+	require.Equal(t, progress.ErrMeasurementFromAnotherConstruction, err)
+}
@@ -0,0 +1,212 @@
+package schedule
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/calendar"
+)
+
+var ErrEmptySchedule = errors.New("schedule has no tasks")
+
+type TaskDates struct {
+	TaskID      string
+	Code        string
+	Name        string
+	EarlyStart  time.Time
+	EarlyFinish time.Time
+	LateStart   time.Time
+	LateFinish  time.Time
+	TotalFloat  int
+	Critical    bool
+}
+
+type Result struct {
+	ScheduleID   string
+	Start        time.Time
+	Finish       time.Time
+	Tasks        []TaskDates
+	CriticalPath []string
+}
+
+func (r Result) Task(taskID string) (TaskDates, bool) {
+	for _, t := range r.Tasks {
+		if t.TaskID == taskID {
+			return t, true
+		}
+	}
+	return TaskDates{}, false
+}
+
+// Planner computes the schedule dates over a calendar with the critical path method.
+type Planner struct {
+	calendar *calendar.Calendar
+}
+
+func NewPlanner(cal *calendar.Calendar) *Planner {
+	return &Planner{calendar: cal}
+}
+
+// Compute runs the forward and backward passes. Actual dates of started and finished tasks
+// replace their early dates, so a slipped task pushes its successors.
+func (p *Planner) Compute(s entity.Schedule) (Result, error) {
+	return p.Forecast(s, time.Time{})
+}
+
+// Forecast computes the schedule as of the status date: work not done by then cannot be done
+// before it, so tasks not started yet start on the status date at the earliest and started
+// tasks not finished yet finish on it at the earliest.
+func (p *Planner) Forecast(s entity.Schedule, statusDate time.Time) (Result, error) {
+	if len(s.Tasks) == 0 {
+		return Result{}, ErrEmptySchedule
+	}
+
+	order := topologicalOrder(s)
+	day0 := p.calendar.NextWorkday(s.StartDate)
+
+	status := 0
+	if !statusDate.IsZero() {
+		status = max(0, p.offset(day0, statusDate))
+	}
+
+	// Early and late dates are workday offsets from day0, finishes being exclusive.
+	es, ef := make(map[string]int), make(map[string]int)
+	for _, t := range order {
+		start := 0
+		for _, d := range s.Dependencies {
+			if d.SuccessorID != t.ID {
+				continue
+			}
+			if d.Type == entity.StartToStart {
+				start = max(start, es[d.PredecessorID]+d.Lag)
+			} else {
+				start = max(start, ef[d.PredecessorID]+d.Lag)
+			}
+		}
+
+		if t.Started() {
+			start = p.offset(day0, t.ActualStart)
+		} else {
+			start = max(start, status)
+		}
+
+		finish := start + t.Duration
+		if t.Finished() {
+			finish = p.offset(day0, t.ActualFinish)
+			if t.Duration > 0 {
+				finish++
+			}
+		} else if t.Started() && t.Duration > 0 {
+			finish = max(finish, status+1)
+		}
+
+		es[t.ID], ef[t.ID] = start, finish
+	}
+
+	end := 0
+	for _, t := range order {
+		end = max(end, ef[t.ID])
+	}
+
+	ls, lf := make(map[string]int), make(map[string]int)
+	for i := len(order) - 1; i >= 0; i-- {
+		t := order[i]
+		length := ef[t.ID] - es[t.ID]
+
+		finish := end
+		for _, d := range s.Dependencies {
+			if d.PredecessorID != t.ID {
+				continue
+			}
+			if d.Type == entity.StartToStart {
+				finish = min(finish, ls[d.SuccessorID]-d.Lag+length)
+			} else {
+				finish = min(finish, ls[d.SuccessorID]-d.Lag)
+			}
+		}
+
+		lf[t.ID], ls[t.ID] = finish, finish-length
+	}
+
+	r := Result{ScheduleID: s.ID, Start: p.date(day0, 0), Finish: p.finishDate(day0, 0, end)}
+	for _, t := range s.Tasks {
+		float := ls[t.ID] - es[t.ID]
+		r.Tasks = append(r.Tasks, TaskDates{
+			TaskID:      t.ID,
+			Code:        t.Code,
+			Name:        t.Name,
+			EarlyStart:  p.date(day0, es[t.ID]),
+			EarlyFinish: p.finishDate(day0, es[t.ID], ef[t.ID]),
+			LateStart:   p.date(day0, ls[t.ID]),
+			LateFinish:  p.finishDate(day0, ls[t.ID], lf[t.ID]),
+			TotalFloat:  float,
+			Critical:    float <= 0,
+		})
+	}
+
+	critical := make([]TaskDates, 0, len(r.Tasks))
+	for _, t := range r.Tasks {
+		if t.Critical {
+			critical = append(critical, t)
+		}
+	}
+	sort.SliceStable(critical, func(i, j int) bool { return es[critical[i].TaskID] < es[critical[j].TaskID] })
+	for _, t := range critical {
+		r.CriticalPath = append(r.CriticalPath, t.TaskID)
+	}
+
+	return r, nil
+}
+
+func (p *Planner) offset(day0 time.Time, d time.Time) int {
+	return p.calendar.Workdays(day0, p.calendar.NextWorkday(d))
+}
+
+func (p *Planner) date(day0 time.Time, offset int) time.Time {
+	return p.calendar.AddWorkdays(day0, offset)
+}
+
+// finishDate is the last workday of a task, or its start for a milestone.
+func (p *Planner) finishDate(day0 time.Time, start int, finish int) time.Time {
+	if finish <= start {
+		return p.date(day0, start)
+	}
+	return p.date(day0, finish-1)
+}
+
+// topologicalOrder sorts the tasks so every predecessor comes before its successors. Schedules
+// refuse cyclic dependencies, so every task is sorted.
+func topologicalOrder(s entity.Schedule) []entity.Task {
+	incoming := make(map[string]int)
+	for _, d := range s.Dependencies {
+		incoming[d.SuccessorID]++
+	}
+
+	var queue, order []entity.Task
+	for _, t := range s.Tasks {
+		if incoming[t.ID] == 0 {
+			queue = append(queue, t)
+		}
+	}
+
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		order = append(order, t)
+
+		for _, d := range s.Dependencies {
+			if d.PredecessorID != t.ID {
+				continue
+			}
+			incoming[d.SuccessorID]--
+			if incoming[d.SuccessorID] == 0 {
+				succ, _ := s.Task(d.SuccessorID)
+				queue = append(queue, succ)
+			}
+		}
+	}
+
+	return order
+}
@@ -0,0 +1,210 @@
+package schedule_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/schedule"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/calendar"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+type plan struct {
+	schedule               entity.Schedule
+	fundacao, estrutura    entity.Task
+	instalacoes, alvenaria entity.Task
+	entrega                entity.Task
+}
+
+func newPlan(t *testing.T) plan {
+	b, err := entity.NewBudget("", uuid.New().String(), decimal.NewFromInt(20), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Obra")
+	require.Nil(t, err)
+
+	s, err := entity.NewSchedule("", b.ConstructionID, b.ID, date(2023, time.April, 3), time.Time{})
+	require.Nil(t, err)
+
+	task := func(code string, name string, duration int) entity.Task {
+		task, err := s.AddTask(b, stage.ID, code, name, duration)
+		require.Nil(t, err)
+		return task
+	}
+
+	p := plan{
+		fundacao:    task("1", "Fundação", 5),
+		estrutura:   task("2", "Estrutura", 10),
+		instalacoes: task("3", "Instalações", 4),
+		alvenaria:   task("4", "Alvenaria", 6),
+		entrega:     task("5", "Entrega", 0),
+	}
+
+	require.Nil(t, s.Link(p.fundacao.ID, p.estrutura.ID, entity.FinishToStart, 0))
+	require.Nil(t, s.Link(p.estrutura.ID, p.instalacoes.ID, entity.StartToStart, 2))
+	require.Nil(t, s.Link(p.estrutura.ID, p.alvenaria.ID, entity.FinishToStart, 0))
+	require.Nil(t, s.Link(p.alvenaria.ID, p.entrega.ID, entity.FinishToStart, 0))
+	require.Nil(t, s.Link(p.instalacoes.ID, p.entrega.ID, entity.FinishToStart, 0))
+
+	p.schedule = s
+	return p
+}
+
+func TestPlanner_Compute(t *testing.T) {
+	p := newPlan(t)
+	planner := schedule.NewPlanner(calendar.NewCalendar())
+
+	r, err := planner.Compute(p.schedule)
+	require.Nil(t, err)
+
+	require.Equal(t, date(2023, time.April, 3), r.Start)
+	require.Equal(t, date(2023, time.May, 4), r.Finish)
+
+	fundacao, _ := r.Task(p.fundacao.ID)
+	require.Equal(t, date(2023, time.April, 10), fundacao.EarlyFinish)
+
+	estrutura, _ := r.Task(p.estrutura.ID)
+	require.Equal(t, date(2023, time.April, 11), estrutura.EarlyStart)
+	require.Equal(t, date(2023, time.April, 25), estrutura.EarlyFinish)
+
+	instalacoes, _ := r.Task(p.instalacoes.ID)
+	require.Equal(t, date(2023, time.April, 13), instalacoes.EarlyStart)
+	require.Equal(t, date(2023, time.April, 18), instalacoes.EarlyFinish)
+	require.Equal(t, date(2023, time.April, 28), instalacoes.LateStart)
+	require.Equal(t, date(2023, time.May, 4), instalacoes.LateFinish)
+	require.Equal(t, 10, instalacoes.TotalFloat)
+	require.False(t, instalacoes.Critical)
+
+	entrega, _ := r.Task(p.entrega.ID)
+	require.Equal(t, date(2023, time.May, 5), entrega.EarlyStart)
+
+	require.Equal(t, []string{p.fundacao.ID, p.estrutura.ID, p.alvenaria.ID, p.entrega.ID}, r.CriticalPath)
+
+	_, err = planner.Compute(entity.Schedule{})
+	require.Equal(t, schedule.ErrEmptySchedule, err)
+}
+
+func TestPlanner_UpdateFromDiary(t *testing.T) {
+	p := newPlan(t)
+	planner := schedule.NewPlanner(calendar.NewCalendar())
+
+	entry := func(d time.Time, taskID string, progress entity.ActivityProgress, sign bool) entity.WorkDiaryEntry {
+		e, err := entity.NewWorkDiaryEntry("", p.schedule.ConstructionID, d, time.Time{})
+		require.Nil(t, err)
+		require.Nil(t, e.AddActivity("Serviço da tarefa", taskID, progress))
+		if sign {
+			require.Nil(t, e.Sign("eng. Maria", d.Add(18*time.Hour)))
+		}
+		return e
+	}
+
+	entries := []entity.WorkDiaryEntry{
+		entry(date(2023, time.April, 6), p.fundacao.ID, entity.ActivityOngoing, true),
+		entry(date(2023, time.April, 5), p.fundacao.ID, entity.ActivityStarted, true),
+		entry(date(2023, time.April, 12), p.fundacao.ID, entity.ActivityFinished, true),
+		entry(date(2023, time.April, 3), p.estrutura.ID, entity.ActivityStarted, false),
+	}
+
+	r, err := planner.UpdateFromDiary(&p.schedule, entries, date(2023, time.April, 12))
+	require.Nil(t, err)
+
+	fundacao, _ := p.schedule.Task(p.fundacao.ID)
+	require.Equal(t, date(2023, time.April, 5), fundacao.ActualStart)
+	require.Equal(t, date(2023, time.April, 12), fundacao.ActualFinish)
+
+	estrutura, _ := p.schedule.Task(p.estrutura.ID)
+	require.False(t, estrutura.Started())
+
+	require.Equal(t, date(2023, time.May, 8), r.Finish)
+	entrega, _ := r.Task(p.entrega.ID)
+	require.Equal(t, date(2023, time.May, 9), entrega.EarlyStart)
+
+	var buf bytes.Buffer
+	require.Nil(t, planner.ExportMSProject(&buf, "Residencial Augusta", p.schedule, r))
+
+	var exported struct {
+		Name        string `xml:"Name"`
+		CalendarUID int    `xml:"CalendarUID"`
+		Calendars   []struct {
+			UID      int `xml:"UID"`
+			WeekDays []struct {
+				DayType    int `xml:"DayType"`
+				DayWorking int `xml:"DayWorking"`
+			} `xml:"WeekDays>WeekDay"`
+			Exceptions []struct {
+				FromDate string `xml:"TimePeriod>FromDate"`
+				Name     string `xml:"Name"`
+			} `xml:"Exceptions>Exception"`
+		} `xml:"Calendars>Calendar"`
+		Tasks []struct {
+			UID          int    `xml:"UID"`
+			Start        string `xml:"Start"`
+			Duration     string `xml:"Duration"`
+			Critical     int    `xml:"Critical"`
+			ActualStart  string `xml:"ActualStart"`
+			Predecessors []struct {
+				PredecessorUID int `xml:"PredecessorUID"`
+				Type           int `xml:"Type"`
+				LinkLag        int `xml:"LinkLag"`
+			} `xml:"PredecessorLink"`
+		} `xml:"Tasks>Task"`
+	}
+	require.Nil(t, xml.Unmarshal(buf.Bytes(), &exported))
+	require.Equal(t, "Residencial Augusta", exported.Name)
+	require.Len(t, exported.Tasks, 5)
+	require.Equal(t, "2023-04-05T08:00:00", exported.Tasks[0].ActualStart)
+	require.Equal(t, "PT40H0M0S", exported.Tasks[0].Duration)
+	require.Equal(t, 1, exported.Tasks[0].Critical)
+	require.Equal(t, 2, exported.Tasks[2].Predecessors[0].PredecessorUID)
+	require.Equal(t, 3, exported.Tasks[2].Predecessors[0].Type)
+	require.Equal(t, 9600, exported.Tasks[2].Predecessors[0].LinkLag)
+
+	require.Len(t, exported.Calendars, 1)
+	calendarXML := exported.Calendars[0]
+	require.Equal(t, exported.CalendarUID, calendarXML.UID)
+	require.Len(t, calendarXML.WeekDays, 7)
+	require.Equal(t, 0, calendarXML.WeekDays[0].DayWorking)
+	require.Equal(t, 1, calendarXML.WeekDays[1].DayWorking)
+
+	holidays := make(map[string]string)
+	for _, e := range calendarXML.Exceptions {
+		holidays[e.FromDate] = e.Name
+	}
+	require.Equal(t, "Sexta-feira Santa", holidays["2023-04-07T00:00:00"])
+	require.Equal(t, "Tiradentes", holidays["2023-04-21T00:00:00"])
+}
+
+func TestPlanner_ForecastLateTask(t *testing.T) {
+	p := newPlan(t)
+	planner := schedule.NewPlanner(calendar.NewCalendar())
+
+	e, err := entity.NewWorkDiaryEntry("", p.schedule.ConstructionID, date(2023, time.April, 3), time.Time{})
+	require.Nil(t, err)
+	require.Nil(t, e.AddActivity("Escavação das sapatas", p.fundacao.ID, entity.ActivityStarted))
+	require.Nil(t, e.Sign("eng. Maria", date(2023, time.April, 3).Add(18*time.Hour)))
+
+	// Planned to finish on April 10th, the foundation is still ongoing on April 14th.
+	r, err := planner.UpdateFromDiary(&p.schedule, []entity.WorkDiaryEntry{e}, date(2023, time.April, 14))
+	require.Nil(t, err)
+
+	fundacao, _ := r.Task(p.fundacao.ID)
+	require.Equal(t, date(2023, time.April, 3), fundacao.EarlyStart)
+	require.Equal(t, date(2023, time.April, 14), fundacao.EarlyFinish)
+
+	estrutura, _ := r.Task(p.estrutura.ID)
+	require.Equal(t, date(2023, time.April, 17), estrutura.EarlyStart)
+
+	// Without a status date the plan still shows the foundation on time.
+	r, err = planner.Compute(p.schedule)
+	require.Nil(t, err)
+	fundacao, _ = r.Task(p.fundacao.ID)
+	require.Equal(t, date(2023, time.April, 10), fundacao.EarlyFinish)
+}
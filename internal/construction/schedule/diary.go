@@ -0,0 +1,66 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+)
+
+// UpdateFromDiary takes the actual start and finish of the tasks from the signed work diary
+// entries of the construction, then forecasts the schedule as of the status date. A task
+// starts on the first day the diary reports work on it and finishes on the day it is reported
+// finished.
+func (p *Planner) UpdateFromDiary(s *entity.Schedule, entries []entity.WorkDiaryEntry,
+	statusDate time.Time) (Result, error) {
+
+	if statusDate.IsZero() {
+		statusDate = time.Now()
+	}
+
+	type actual struct{ start, finish time.Time }
+	actuals := make(map[string]actual)
+
+	for _, e := range entries {
+		if e.ConstructionID != s.ConstructionID || !e.IsSigned() {
+			continue
+		}
+
+		for _, a := range e.Activities {
+			if a.TaskID == "" {
+				continue
+			}
+
+			dates := actuals[a.TaskID]
+			if dates.start.IsZero() || e.Date.Before(dates.start) {
+				dates.start = e.Date
+			}
+			if a.Progress == entity.ActivityFinished || a.Progress == entity.ActivityStartedAndFinished {
+				if e.Date.After(dates.finish) {
+					dates.finish = e.Date
+				}
+			}
+			actuals[a.TaskID] = dates
+		}
+	}
+
+	for _, t := range s.Tasks {
+		dates, ok := actuals[t.ID]
+		if !ok {
+			continue
+		}
+
+		if t.Started() && t.ActualStart.Before(dates.start) {
+			dates.start = t.ActualStart
+		}
+
+		if dates.finish.IsZero() {
+			dates.finish = t.ActualFinish
+		}
+
+		if err := s.SetActualDates(t.ID, dates.start, dates.finish); err != nil {
+			return Result{}, err
+		}
+	}
+
+	return p.Forecast(*s, statusDate)
+}
@@ -0,0 +1,215 @@
+package schedule
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+)
+
+// MS Project XML constants. Lags are written in tenths of minutes, with days as lag format.
+const (
+	msProjectNamespace  = "http://schemas.microsoft.com/project"
+	msProjectDateFormat = "2006-01-02T15:04:05"
+	msFinishToStart     = 1
+	msStartToStart      = 3
+	msLagFormatDays     = 7
+	msCalendarUID       = 1
+	msExceptionDaily    = 1
+	hoursPerDay         = 8
+	workdayStartHour    = 8
+	workdayFinishHour   = 17
+)
+
+// Working times of a workday, with an hour for lunch, so a day has hoursPerDay hours.
+var msWorkingTimes = []msWorkingTime{{From: "08:00:00", To: "12:00:00"}, {From: "13:00:00", To: "17:00:00"}}
+
+type msProject struct {
+	XMLName     xml.Name     `xml:"Project"`
+	Namespace   string       `xml:"xmlns,attr"`
+	Name        string       `xml:"Name"`
+	StartDate   string       `xml:"StartDate"`
+	FinishDate  string       `xml:"FinishDate"`
+	CalendarUID int          `xml:"CalendarUID"`
+	Calendars   []msCalendar `xml:"Calendars>Calendar"`
+	Tasks       []msTask     `xml:"Tasks>Task"`
+}
+
+type msCalendar struct {
+	UID            int           `xml:"UID"`
+	Name           string        `xml:"Name"`
+	IsBaseCalendar int           `xml:"IsBaseCalendar"`
+	WeekDays       []msWeekDay   `xml:"WeekDays>WeekDay"`
+	Exceptions     []msException `xml:"Exceptions>Exception"`
+}
+
+// msWeekDay DayType is the day of the week from 1, Sunday, to 7, Saturday.
+type msWeekDay struct {
+	DayType      int             `xml:"DayType"`
+	DayWorking   int             `xml:"DayWorking"`
+	WorkingTimes []msWorkingTime `xml:"WorkingTimes>WorkingTime,omitempty"`
+}
+
+type msWorkingTime struct {
+	From string `xml:"FromTime"`
+	To   string `xml:"ToTime"`
+}
+
+type msException struct {
+	EnteredByOccurrences int          `xml:"EnteredByOccurrences"`
+	TimePeriod           msTimePeriod `xml:"TimePeriod"`
+	Occurrences          int          `xml:"Occurrences"`
+	Name                 string       `xml:"Name"`
+	Type                 int          `xml:"Type"`
+	DayWorking           int          `xml:"DayWorking"`
+}
+
+type msTimePeriod struct {
+	FromDate string `xml:"FromDate"`
+	ToDate   string `xml:"ToDate"`
+}
+
+type msTask struct {
+	UID          int             `xml:"UID"`
+	ID           int             `xml:"ID"`
+	Name         string          `xml:"Name"`
+	WBS          string          `xml:"WBS"`
+	Start        string          `xml:"Start"`
+	Finish       string          `xml:"Finish"`
+	Duration     string          `xml:"Duration"`
+	Milestone    int             `xml:"Milestone"`
+	Critical     int             `xml:"Critical"`
+	TotalSlack   string          `xml:"TotalSlack"`
+	ActualStart  string          `xml:"ActualStart,omitempty"`
+	ActualFinish string          `xml:"ActualFinish,omitempty"`
+	Predecessors []msPredecessor `xml:"PredecessorLink"`
+}
+
+type msPredecessor struct {
+	PredecessorUID int `xml:"PredecessorUID"`
+	Type           int `xml:"Type"`
+	LinkLag        int `xml:"LinkLag"`
+	LagFormat      int `xml:"LagFormat"`
+}
+
+// ExportMSProject writes the computed schedule as MS Project XML, which MS Project and most
+// planning tools import. The project calendar has the workdays and holidays of the planner, so
+// the tools compute the same dates.
+func (p *Planner) ExportMSProject(w io.Writer, name string, s entity.Schedule, r Result) error {
+	uids := make(map[string]int, len(s.Tasks))
+	for i, t := range s.Tasks {
+		uids[t.ID] = i + 1
+	}
+
+	project := msProject{
+		Namespace:   msProjectNamespace,
+		Name:        name,
+		StartDate:   msDate(r.Start, workdayStartHour),
+		FinishDate:  msDate(r.Finish, workdayFinishHour),
+		CalendarUID: msCalendarUID,
+		Calendars:   []msCalendar{p.msCalendar(r.Start, r.Finish)},
+	}
+
+	for i, t := range s.Tasks {
+		dates, ok := r.Task(t.ID)
+		if !ok {
+			return fmt.Errorf("%w: %s", entity.ErrTaskNotFound, t.ID)
+		}
+
+		task := msTask{
+			UID:        uids[t.ID],
+			ID:         i + 1,
+			Name:       t.Name,
+			WBS:        t.Code,
+			Start:      msDate(dates.EarlyStart, workdayStartHour),
+			Finish:     msDate(dates.EarlyFinish, workdayFinishHour),
+			Duration:   msDuration(t.Duration),
+			Milestone:  msBool(t.Duration == 0),
+			Critical:   msBool(dates.Critical),
+			TotalSlack: msDuration(dates.TotalFloat),
+		}
+
+		if t.Started() {
+			task.ActualStart = msDate(t.ActualStart, workdayStartHour)
+		}
+		if t.Finished() {
+			task.ActualFinish = msDate(t.ActualFinish, workdayFinishHour)
+		}
+
+		for _, d := range s.Dependencies {
+			if d.SuccessorID != t.ID {
+				continue
+			}
+
+			linkType := msFinishToStart
+			if d.Type == entity.StartToStart {
+				linkType = msStartToStart
+			}
+
+			task.Predecessors = append(task.Predecessors, msPredecessor{
+				PredecessorUID: uids[d.PredecessorID],
+				Type:           linkType,
+				LinkLag:        d.Lag * hoursPerDay * 60 * 10,
+				LagFormat:      msLagFormatDays,
+			})
+		}
+
+		project.Tasks = append(project.Tasks, task)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(project)
+}
+
+// msCalendar lists the holidays of every year of the schedule as exceptions.
+func (p *Planner) msCalendar(start time.Time, finish time.Time) msCalendar {
+	cal := msCalendar{UID: msCalendarUID, Name: "Padrão", IsBaseCalendar: 1}
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		day := msWeekDay{DayType: int(d) + 1}
+		if d != time.Saturday && d != time.Sunday {
+			day.DayWorking = 1
+			day.WorkingTimes = msWorkingTimes
+		}
+		cal.WeekDays = append(cal.WeekDays, day)
+	}
+
+	for year := start.Year(); year <= finish.Year(); year++ {
+		for _, h := range p.calendar.Holidays(year) {
+			endOfDay := time.Date(h.Date.Year(), h.Date.Month(), h.Date.Day(), 23, 59, 0, 0, time.UTC)
+			cal.Exceptions = append(cal.Exceptions, msException{
+				TimePeriod:  msTimePeriod{FromDate: msDate(h.Date, 0), ToDate: endOfDay.Format(msProjectDateFormat)},
+				Occurrences: 1,
+				Name:        h.Name,
+				Type:        msExceptionDaily,
+			})
+		}
+	}
+
+	return cal
+}
+
+func msDate(d time.Time, hour int) string {
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, 0, 0, 0, time.UTC).Format(msProjectDateFormat)
+}
+
+func msDuration(days int) string {
+	if days < 0 {
+		return fmt.Sprintf("-PT%dH0M0S", -days*hoursPerDay)
+	}
+	return fmt.Sprintf("PT%dH0M0S", days*hoursPerDay)
+}
+
+func msBool(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
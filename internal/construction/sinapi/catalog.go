@@ -0,0 +1,140 @@
+package sinapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/shopspring/decimal"
+)
+
+const Source = "SINAPI"
+
+type Kind string
+
+const (
+	KindInput       Kind = "input"
+	KindComposition Kind = "composition"
+)
+
+var ErrPriceNotFound = errors.New("SINAPI reference price not found")
+
+// Key identifies a reference price. SINAPI publishes one table per state and month, with and
+// without the payroll tax exemption (desoneração).
+type Key struct {
+	Code      string
+	State     string
+	Month     string
+	TaxExempt bool
+}
+
+type ReferencePrice struct {
+	Code        string
+	Description string
+	Unit        string
+	Kind        Kind
+	State       string
+	Month       time.Time
+	TaxExempt   bool
+	Price       decimal.Decimal
+}
+
+func (p ReferencePrice) Key() Key {
+	return NewKey(p.Code, p.State, p.Month, p.TaxExempt)
+}
+
+func NewKey(code string, state string, month time.Time, taxExempt bool) Key {
+	return Key{
+		Code:      strings.TrimSpace(code),
+		State:     strings.ToUpper(state),
+		Month:     month.Format("2006-01"),
+		TaxExempt: taxExempt,
+	}
+}
+
+// Snapshot freezes the price to be kept by a budget item.
+func (p ReferencePrice) Snapshot(at time.Time) entity.PriceReference {
+	return entity.PriceReference{
+		Source:     Source,
+		Code:       p.Code,
+		State:      p.State,
+		Month:      p.Month,
+		TaxExempt:  p.TaxExempt,
+		UnitPrice:  p.Price,
+		SnapshotAt: at,
+	}
+}
+
+// Catalog keeps the imported reference prices in memory. It is safe for concurrent use.
+type Catalog struct {
+	mu     sync.RWMutex
+	prices map[Key]ReferencePrice
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{prices: make(map[Key]ReferencePrice)}
+}
+
+// Add stores the prices, replacing any price already imported with the same key.
+func (c *Catalog) Add(prices ...ReferencePrice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range prices {
+		c.prices[p.Key()] = p
+	}
+}
+
+func (c *Catalog) Lookup(code string, state string, month time.Time, taxExempt bool) (ReferencePrice, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	key := NewKey(code, state, month, taxExempt)
+	p, ok := c.prices[key]
+	if !ok {
+		return ReferencePrice{}, fmt.Errorf("%w: %s %s %s exempt=%t", ErrPriceNotFound, key.Code, key.State, key.Month,
+			key.TaxExempt)
+	}
+	return p, nil
+}
+
+// Months lists the reference months imported for a state, oldest first.
+func (c *Catalog) Months(state string, taxExempt bool) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	for k := range c.prices {
+		if k.State == strings.ToUpper(state) && k.TaxExempt == taxExempt {
+			seen[k.Month] = true
+		}
+	}
+
+	months := make([]string, 0, len(seen))
+	for m := range seen {
+		months = append(months, m)
+	}
+	sort.Strings(months)
+	return months
+}
+
+// PriceBudgetItem prices a budget item with the catalog price in force for the budget
+// state, month and tax exemption mode.
+func (c *Catalog) PriceBudgetItem(b *entity.Budget, itemID string, code string, state string, month time.Time,
+	taxExempt bool, at time.Time) error {
+
+	price, err := c.Lookup(code, state, month, taxExempt)
+	if err != nil {
+		return err
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	return b.PriceItemFromReference(itemID, price.Snapshot(at))
+}
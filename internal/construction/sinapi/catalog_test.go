@@ -0,0 +1,59 @@
+package sinapi_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/sinapi"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog_PriceBudgetItem(t *testing.T) {
+	jan := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	catalog := sinapi.NewCatalog()
+	catalog.Add(
+		sinapi.ReferencePrice{Code: "34493", Unit: "M3", Kind: sinapi.KindInput, State: "SP", Month: jan, TaxExempt: true,
+			Price: decimal.RequireFromString("512.37")},
+		sinapi.ReferencePrice{Code: "34493", Unit: "M3", Kind: sinapi.KindInput, State: "SP", Month: jan,
+			Price: decimal.RequireFromString("540.10")},
+		sinapi.ReferencePrice{Code: "34493", Unit: "M3", Kind: sinapi.KindInput, State: "SP", Month: feb, TaxExempt: true,
+			Price: decimal.RequireFromString("515.02")},
+	)
+
+	require.Equal(t, []string{"2023-01", "2023-02"}, catalog.Months("sp", true))
+
+	_, err := catalog.Lookup("34493", "RJ", jan, true)
+	require.True(t, errors.Is(err, sinapi.ErrPriceNotFound))
+
+	b, err := entity.NewBudget("", uuid.New().String(), decimal.RequireFromString("25"), time.Time{})
+	require.Nil(t, err)
+	stage, err := b.AddStage("", "1", "Estrutura")
+	require.Nil(t, err)
+	item, err := b.AddItem(stage.ID, entity.BudgetItem{
+		Code: "1.1", Description: "Concreto usinado", Unit: "m3", Quantity: decimal.RequireFromString("10"),
+		UnitCost: decimal.RequireFromString("400"),
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, catalog.PriceBudgetItem(&b, item.ID, "34493", "SP", jan, false, time.Time{}))
+
+	priced, ok := b.Item(item.ID)
+	require.True(t, ok)
+	require.True(t, decimal.RequireFromString("540.10").Equal(priced.UnitCost))
+	require.NotNil(t, priced.Reference)
+	require.Equal(t, sinapi.Source, priced.Reference.Source)
+	require.Equal(t, "2023-01", priced.Reference.Month.Format("2006-01"))
+	require.False(t, priced.Reference.TaxExempt)
+
+	catalog.Add(sinapi.ReferencePrice{Code: "34493", Unit: "M3", Kind: sinapi.KindInput, State: "SP", Month: jan,
+		Price: decimal.RequireFromString("999")})
+
+	priced, _ = b.Item(item.ID)
+	require.True(t, decimal.RequireFromString("540.10").Equal(priced.UnitCost))
+}
@@ -0,0 +1,216 @@
+package sinapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrHeaderNotFound  = errors.New("SINAPI header row not found")
+	ErrUnknownFileName = errors.New("file name does not follow the SINAPI naming")
+)
+
+// Table describes what a SINAPI file holds, which is not part of its rows.
+type Table struct {
+	Kind      Kind
+	State     string
+	Month     time.Time
+	TaxExempt bool
+}
+
+// Files are published as SINAPI_Preco_Ref_Insumos_SP_202301_Desonerado.xlsx and
+// SINAPI_Custo_Ref_Composicoes_Sintetico_SP_202301_NaoDesonerado.xlsx.
+var fileNameRegex = regexp.MustCompile(`(?i)(insumos|composicoes).*_([A-Z]{2})_(\d{6})_(desonerado|naodesonerado|nao_desonerado)`)
+
+// ParseFileName reads the table description from a SINAPI file name.
+func ParseFileName(name string) (Table, error) {
+	m := fileNameRegex.FindStringSubmatch(filepath.Base(name))
+	if m == nil {
+		return Table{}, fmt.Errorf("%w: %s", ErrUnknownFileName, name)
+	}
+
+	month, err := time.Parse("200601", m[3])
+	if err != nil {
+		return Table{}, fmt.Errorf("%w: %s", ErrUnknownFileName, name)
+	}
+
+	kind := KindInput
+	if strings.EqualFold(m[1], "composicoes") {
+		kind = KindComposition
+	}
+
+	return Table{
+		Kind:      kind,
+		State:     strings.ToUpper(m[2]),
+		Month:     month,
+		TaxExempt: strings.EqualFold(m[4], "desonerado"),
+	}, nil
+}
+
+// headers lists the column names, without accents and upper cased, of a SINAPI table. The
+// synthetic composition table also has class and group columns, such as "DESCRICAO DA CLASSE"
+// and "CODIGO DO AGRUPADOR", so names are matched exactly.
+type headers struct {
+	code, description, unit, price []string
+}
+
+var tableHeaders = map[Kind]headers{
+	KindInput: {
+		code:        []string{"CODIGO"},
+		description: []string{"DESCRICAO DO INSUMO"},
+		unit:        []string{"UNIDADE DE MEDIDA", "UNIDADE"},
+		price:       []string{"PRECO MEDIANO R$"},
+	},
+	KindComposition: {
+		code:        []string{"CODIGO DA COMPOSICAO"},
+		description: []string{"DESCRICAO DA COMPOSICAO"},
+		unit:        []string{"UNIDADE"},
+		price:       []string{"CUSTO TOTAL"},
+	},
+}
+
+func ImportCSV(r io.Reader, table Table) ([]ReferencePrice, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading SINAPI csv: %w", err)
+	}
+
+	return parseRows(rows, table)
+}
+
+func ImportXLSX(r io.ReaderAt, size int64, table Table) ([]ReferencePrice, error) {
+	rows, err := readXLSX(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRows(rows, table)
+}
+
+// parseRows skips the title rows SINAPI puts above the header, then reads every row with a
+// numeric code and a price. Group headings between items have no price and are skipped.
+func parseRows(rows [][]string, table Table) ([]ReferencePrice, error) {
+	headerIdx, cols := -1, columns{}
+	for i, row := range rows {
+		if c, ok := findColumns(row, tableHeaders[table.Kind]); ok {
+			headerIdx, cols = i, c
+			break
+		}
+	}
+
+	if headerIdx < 0 {
+		return nil, ErrHeaderNotFound
+	}
+
+	var prices []ReferencePrice
+	for _, row := range rows[headerIdx+1:] {
+		code := strings.TrimSpace(cell(row, cols.code))
+		priceText := strings.TrimSpace(cell(row, cols.price))
+		if code == "" || !isDigits(code) || priceText == "" {
+			continue
+		}
+
+		price, err := parseNumber(priceText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price %q for code %s: %w", priceText, code, err)
+		}
+
+		prices = append(prices, ReferencePrice{
+			Code:        code,
+			Description: strings.TrimSpace(cell(row, cols.description)),
+			Unit:        strings.TrimSpace(cell(row, cols.unit)),
+			Kind:        table.Kind,
+			State:       table.State,
+			Month:       table.Month,
+			TaxExempt:   table.TaxExempt,
+			Price:       price,
+		})
+	}
+
+	return prices, nil
+}
+
+type columns struct {
+	code, description, unit, price int
+}
+
+func findColumns(row []string, h headers) (columns, bool) {
+	names := make([]string, len(row))
+	for i, name := range row {
+		names[i] = normalizeHeader(name)
+	}
+
+	c := columns{
+		code:        findHeader(names, h.code),
+		description: findHeader(names, h.description),
+		unit:        findHeader(names, h.unit),
+		price:       findHeader(names, h.price),
+	}
+
+	return c, c.code >= 0 && c.description >= 0 && c.price >= 0
+}
+
+// findHeader returns the column named as one of the candidates, in the order of preference of
+// the candidates.
+func findHeader(names []string, candidates []string) int {
+	for _, candidate := range candidates {
+		for i, name := range names {
+			if name == candidate {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func normalizeHeader(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, s)
+	if err != nil {
+		stripped = s
+	}
+	return strings.Join(strings.Fields(strings.ToUpper(stripped)), " ")
+}
+
+func cell(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+	return row[idx]
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNumber accepts both the Brazilian notation used in the CSV files ("1.234,56") and the
+// raw numbers stored in XLSX cells ("1234.56").
+func parseNumber(s string) (decimal.Decimal, error) {
+	if strings.Contains(s, ",") {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+	return decimal.NewFromString(s)
+}
@@ -0,0 +1,183 @@
+package sinapi_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/construction/sinapi"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinapi_ParseFileName(t *testing.T) {
+	type testCase struct {
+		test          string
+		name          string
+		expectedTable sinapi.Table
+		expectedErr   error
+	}
+
+	testCases := []testCase{
+		{
+			test: "Should parse an input table file name",
+			name: "/tmp/SINAPI_Preco_Ref_Insumos_SP_202301_Desonerado.xlsx",
+			expectedTable: sinapi.Table{
+				Kind: sinapi.KindInput, State: "SP", Month: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), TaxExempt: true,
+			},
+		},
+		{
+			test: "Should parse a synthetic composition table file name",
+			name: "SINAPI_Custo_Ref_Composicoes_Sintetico_MG_202312_NaoDesonerado.csv",
+			expectedTable: sinapi.Table{
+				Kind: sinapi.KindComposition, State: "MG", Month: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			test:        "Should reject a file name outside the SINAPI naming",
+			name:        "precos.xlsx",
+			expectedErr: sinapi.ErrUnknownFileName,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		table, err := sinapi.ParseFileName(tc.name)
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr))
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedTable, table)
+	}
+}
+
+func TestSinapi_ImportCSV(t *testing.T) {
+	table := sinapi.Table{Kind: sinapi.KindInput, State: "SP", Month: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	type testCase struct {
+		test           string
+		content        string
+		expectedPrices map[string]string
+		expectedErr    error
+	}
+
+	testCases := []testCase{
+		{
+			test: "Should skip the title rows and read the Brazilian number format",
+			content: "SISTEMA NACIONAL DE PESQUISA DE CUSTOS E ÍNDICES DA CONSTRUÇÃO CIVIL;;;;\n" +
+				"MÊS DE COLETA: 01/2023;;;;\n" +
+				";;;;\n" +
+				"CÓDIGO  ;DESCRIÇÃO DO INSUMO;UNIDADE DE MEDIDA;ORIGEM DE PREÇO;PREÇO MEDIANO R$\n" +
+				"34493;CONCRETO USINADO BOMBEAVEL, CLASSE DE RESISTENCIA C25;M3;C;512,37\n" +
+				"00001;MATERIAIS BÁSICOS;;;\n" +
+				"43059;ACO CA-50, 10,0 MM, VERGALHAO;KG;CR;1.234,56\n",
+			expectedPrices: map[string]string{"34493": "512.37", "43059": "1234.56"},
+		},
+		{
+			test:        "Should fail without the header row",
+			content:     "34493;CONCRETO;M3;512,37\n",
+			expectedErr: sinapi.ErrHeaderNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		prices, err := sinapi.ImportCSV(strings.NewReader(tc.content), table)
+		if tc.expectedErr != nil {
+			require.Equal(t, tc.expectedErr, err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Len(t, prices, len(tc.expectedPrices))
+		for _, p := range prices {
+			require.True(t, decimal.RequireFromString(tc.expectedPrices[p.Code]).Equal(p.Price), p.Code)
+			require.Equal(t, "SP", p.State)
+			require.Equal(t, sinapi.KindInput, p.Kind)
+			require.NotEmpty(t, p.Unit)
+		}
+	}
+}
+
+func TestSinapi_ImportXLSX(t *testing.T) {
+	content := buildXLSX(t,
+		[]string{"Composições sintéticas", "ÍNDICE", "CUSTO"},
+		`<row r="1"><c r="A1" t="s"><v>0</v></c></row>`+
+			xlsxRow(3, "DESCRIÇÃO DA CLASSE", "SIGLA DA CLASSE", "DESCRIÇÃO DO TIPO 1", "SIGLA DO TIPO 1",
+				"CÓDIGO DO AGRUPADOR", "DESCRIÇÃO DO AGRUPADOR", "CÓDIGO DA COMPOSIÇÃO", "DESCRIÇÃO DA COMPOSIÇÃO",
+				"UNIDADE", "ORIGEM DE PREÇO", "CUSTO TOTAL", "VINCULO")+
+			`<row r="4"><c r="A4" t="inlineStr"><is><t>CONCRETOS</t></is></c>`+
+			`<c r="B4" t="inlineStr"><is><t>CONC</t></is></c>`+
+			`<c r="E4" t="inlineStr"><is><t>5928</t></is></c>`+
+			`<c r="F4" t="inlineStr"><is><t>CONCRETO PREPARADO EM OBRA</t></is></c>`+
+			`<c r="G4" t="inlineStr"><is><t>94965</t></is></c>`+
+			`<c r="H4" t="inlineStr"><is><r><t>CONCRETO FCK = 25MPA, </t></r><r><t>PREPARO MECÂNICO</t></r></is></c>`+
+			`<c r="I4" t="inlineStr"><is><t>M3</t></is></c>`+
+			`<c r="J4" t="inlineStr"><is><t>CR</t></is></c>`+
+			`<c r="K4"><v>487.5</v></c></row>`)
+
+	table := sinapi.Table{
+		Kind: sinapi.KindComposition, State: "RJ", Month: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), TaxExempt: true,
+	}
+
+	prices, err := sinapi.ImportXLSX(bytes.NewReader(content), int64(len(content)), table)
+	require.Nil(t, err)
+	require.Len(t, prices, 1)
+	require.Equal(t, "94965", prices[0].Code)
+	require.Equal(t, "CONCRETO FCK = 25MPA, PREPARO MECÂNICO", prices[0].Description)
+	require.Equal(t, "M3", prices[0].Unit)
+	require.True(t, decimal.RequireFromString("487.5").Equal(prices[0].Price))
+	require.True(t, prices[0].TaxExempt)
+
+	for _, ref := range []string{"12", "a1", "XFE1", "ZZZZZZZ1"} {
+		malformed := buildXLSX(t, nil, `<row r="1"><c r="`+ref+`" t="inlineStr"><is><t>CÓDIGO</t></is></c></row>`)
+		_, err = sinapi.ImportXLSX(bytes.NewReader(malformed), int64(len(malformed)), table)
+		require.Equal(t, fmt.Errorf("invalid cell reference %q", ref), err)
+	}
+}
+
+// xlsxRow writes a row of inline strings starting at column A.
+func xlsxRow(n int, cells ...string) string {
+	var row strings.Builder
+	fmt.Fprintf(&row, `<row r="%d">`, n)
+	for i, c := range cells {
+		fmt.Fprintf(&row, `<c r="%c%d" t="inlineStr"><is><t>%s</t></is></c>`, 'A'+i, n, c)
+	}
+	row.WriteString("</row>")
+	return row.String()
+}
+
+func buildXLSX(t *testing.T, shared []string, rows string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	var sst strings.Builder
+	sst.WriteString(`<?xml version="1.0" encoding="UTF-8"?><sst>`)
+	for _, s := range shared {
+		sst.WriteString("<si><t>" + s + "</t></si>")
+	}
+	sst.WriteString("</sst>")
+
+	files := map[string]string{
+		"xl/sharedStrings.xml":     sst.String(),
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?><worksheet><sheetData>` + rows + `</sheetData></worksheet>`,
+	}
+
+	for name, body := range files {
+		w, err := zw.Create(name)
+		require.Nil(t, err)
+		_, err = w.Write([]byte(body))
+		require.Nil(t, err)
+	}
+	require.Nil(t, zw.Close())
+
+	return buf.Bytes()
+}
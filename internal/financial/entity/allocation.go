@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+const moneyPlaces = 2
+
+var cent = decimal.New(1, -moneyPlaces)
+
+var (
+	ErrNegativeAllocation = errors.New("amount to allocate pro rata is negative")
+	ErrNegativeWeight     = errors.New("pro rata weight is negative")
+	ErrNoWeights          = errors.New("pro rata allocation has no positive weight")
+)
+
+// Share is a claim on an amount to be split, in proportion to its weight.
+type Share struct {
+	Key    string
+	Weight decimal.Decimal
+}
+
+// AllocateProRata splits total in cents proportionally to the weights, so that the parts
+// always add up to total. Every part is first rounded down, then the cents left go one by
+// one to the largest remainders, ties going to the smallest key, so a small share may get
+// nothing when there are fewer cents than shares. Shares weighing zero get nothing. The
+// result follows the order of the shares.
+func AllocateProRata(total decimal.Decimal, shares []Share) ([]decimal.Decimal, error) {
+	if total.IsNegative() {
+		return nil, ErrNegativeAllocation
+	}
+
+	weights := decimal.Zero
+	for _, s := range shares {
+		if s.Weight.IsNegative() {
+			return nil, fmt.Errorf("%w: %s", ErrNegativeWeight, s.Key)
+		}
+		weights = weights.Add(s.Weight)
+	}
+
+	if !weights.IsPositive() {
+		return nil, ErrNoWeights
+	}
+
+	total = total.Round(moneyPlaces)
+	parts := make([]decimal.Decimal, len(shares))
+	remainders := make([]decimal.Decimal, len(shares))
+	allocated := decimal.Zero
+	var order []int
+	for i, s := range shares {
+		exact := total.Mul(s.Weight).Div(weights)
+		parts[i] = exact.RoundDown(moneyPlaces)
+		remainders[i] = exact.Sub(parts[i])
+		allocated = allocated.Add(parts[i])
+		if s.Weight.IsPositive() {
+			order = append(order, i)
+		}
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		ra, rb := remainders[order[a]], remainders[order[b]]
+		if !ra.Equal(rb) {
+			return ra.GreaterThan(rb)
+		}
+		return shares[order[a]].Key < shares[order[b]].Key
+	})
+
+	left := total.Sub(allocated).Div(cent).IntPart()
+	for i := 0; int64(i) < left; i++ {
+		idx := order[i%len(order)]
+		parts[idx] = parts[idx].Add(cent)
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,118 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/entity"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestAllocateProRata(t *testing.T) {
+	type testCase struct {
+		test        string
+		total       string
+		shares      []entity.Share
+		expected    []string
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:  "Should give the cent left to the smallest key on ties",
+			total: "100",
+			shares: []entity.Share{
+				{Key: "c", Weight: dec("1")},
+				{Key: "a", Weight: dec("1")},
+				{Key: "b", Weight: dec("1")},
+			},
+			expected: []string{"33.33", "33.34", "33.33"},
+		},
+		{
+			test:  "Should give the cents left to the largest remainders",
+			total: "1000.01",
+			shares: []entity.Share{
+				{Key: "a", Weight: dec("20")},
+				{Key: "b", Weight: dec("30")},
+				{Key: "c", Weight: dec("50")},
+			},
+			expected: []string{"200", "300", "500.01"},
+		},
+		{
+			test:  "Should split by the weights even when they do not add up to 100",
+			total: "999.99",
+			shares: []entity.Share{
+				{Key: "a", Weight: dec("10")},
+				{Key: "b", Weight: dec("20")},
+			},
+			expected: []string{"333.33", "666.66"},
+		},
+		{
+			test:  "Should leave nothing to the smallest shares when the cents run out",
+			total: "0.05",
+			shares: []entity.Share{
+				{Key: "a", Weight: dec("20000")},
+				{Key: "b", Weight: dec("20000")},
+				{Key: "c", Weight: dec("20000")},
+				{Key: "d", Weight: dec("20000")},
+				{Key: "e", Weight: dec("150000")},
+			},
+			expected: []string{"0.01", "0.01", "0", "0", "0.03"},
+		},
+		{
+			test:  "Should give nothing to shares weighing zero",
+			total: "10",
+			shares: []entity.Share{
+				{Key: "a", Weight: decimal.Zero},
+				{Key: "b", Weight: dec("1")},
+			},
+			expected: []string{"0", "10"},
+		},
+		{
+			test:        "Should reject allocations without weights",
+			total:       "10",
+			shares:      []entity.Share{{Key: "a", Weight: decimal.Zero}},
+			expectedErr: entity.ErrNoWeights,
+		},
+		{
+			test:  "Should reject a negative total",
+			total: "-10.01",
+			shares: []entity.Share{
+				{Key: "a", Weight: dec("1")},
+				{Key: "b", Weight: dec("2")},
+			},
+			expectedErr: entity.ErrNegativeAllocation,
+		},
+		{
+			test:  "Should reject a negative weight",
+			total: "10",
+			shares: []entity.Share{
+				{Key: "a", Weight: dec("-1")},
+				{Key: "b", Weight: dec("2")},
+			},
+			expectedErr: entity.ErrNegativeWeight,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		parts, err := entity.AllocateProRata(dec(tc.total), tc.shares)
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr), "%v", err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Len(t, parts, len(tc.expected))
+		for i, p := range parts {
+			require.True(t, dec(tc.expected[i]).Equal(p), "%s != %s", tc.expected[i], p)
+		}
+	}
+}
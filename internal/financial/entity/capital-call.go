@@ -0,0 +1,346 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNoInvestors           = errors.New("construction has no investing partners to call capital from")
+	ErrAllocationNotFound    = errors.New("partner is not part of the capital call")
+	ErrPaymentBeforeIssuance = errors.New("payment date is before the capital call was issued")
+	ErrPaymentOverBalance    = errors.New("payment exceeds the balance of the partner in the capital call")
+)
+
+// LatePolicy is charged on the principal not paid by the due date. Payments within the grace
+// period carry no charges. Past it, a one-time penalty (multa) applies and interest accrues pro
+// rata die from the due date on the principal still unpaid, even if part of it was paid within
+// the grace period.
+type LatePolicy struct {
+	PenaltyPercent         decimal.Decimal `validate:"gte=0,lte=100"`
+	MonthlyInterestPercent decimal.Decimal `validate:"gte=0"`
+	GraceDays              int             `validate:"gte=0"`
+}
+
+type CallPayment struct {
+	Amount decimal.Decimal `validate:"gt=0"`
+	PaidAt time.Time       `validate:"required"`
+}
+
+type CallAllocation struct {
+	PartnerID    string          `validate:"required,uuid"`
+	InvestmentID string          `validate:"required,uuid"`
+	Percentage   decimal.Decimal `validate:"gt=0,lte=100"`
+	Amount       decimal.Decimal `validate:"gte=0"`
+	Payments     []CallPayment   `validate:"dive"`
+}
+
+// CapitalCall asks the investing partners of a construction for cash, split pro rata to their
+// share of the construction.
+type CapitalCall struct {
+	ID             string           `validate:"required,uuid"`
+	ConstructionID string           `validate:"required,uuid"`
+	Purpose        string           `validate:"required,min=3"`
+	Amount         decimal.Decimal  `validate:"gt=0"`
+	DueDate        time.Time        `validate:"required"`
+	Policy         LatePolicy       `validate:""`
+	Allocations    []CallAllocation `validate:"required,min=1,dive"`
+	CreatedAt      time.Time        `validate:"required"`
+}
+
+func NewCapitalCall(id string, constructionID string, purpose string, amount decimal.Decimal, dueDate time.Time,
+	policy LatePolicy, investments []construction.Investment, createdAt time.Time) (CapitalCall, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	var investors []construction.Investment
+	for _, inv := range investments {
+		if inv.ConstructionID == constructionID {
+			investors = append(investors, inv)
+		}
+	}
+
+	if len(investors) == 0 {
+		return CapitalCall{}, ErrNoInvestors
+	}
+
+	if err := construction.ValidateInvestors(constructionID, investors); err != nil {
+		return CapitalCall{}, err
+	}
+
+	shares := make([]Share, len(investors))
+	for i, inv := range investors {
+		shares[i] = Share{Key: inv.PartnerID, Weight: inv.Percentage}
+	}
+
+	call := CapitalCall{
+		ID:             id,
+		ConstructionID: constructionID,
+		Purpose:        purpose,
+		Amount:         amount.Round(moneyPlaces),
+		DueDate:        day(dueDate),
+		Policy:         policy,
+		CreatedAt:      createdAt,
+	}
+
+	parts, err := AllocateProRata(call.Amount, shares)
+	if err != nil {
+		return CapitalCall{}, err
+	}
+
+	for i, part := range parts {
+		call.Allocations = append(call.Allocations, CallAllocation{
+			PartnerID:    investors[i].PartnerID,
+			InvestmentID: investors[i].ID,
+			Percentage:   investors[i].Percentage,
+			Amount:       part,
+		})
+	}
+
+	return call, validateCapitalCall(call)
+}
+
+func (c CapitalCall) Allocation(partnerID string) (CallAllocation, bool) {
+	for _, a := range c.Allocations {
+		if a.PartnerID == partnerID {
+			return a, true
+		}
+	}
+	return CallAllocation{}, false
+}
+
+// RegisterPayment records a payment of the partner, which may not exceed the balance, with
+// charges, on the date of the partner's last payment.
+func (c *CapitalCall) RegisterPayment(partnerID string, amount decimal.Decimal, paidAt time.Time) error {
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	if paidAt.Before(c.CreatedAt) {
+		return ErrPaymentBeforeIssuance
+	}
+
+	updated := *c
+	updated.Allocations = append([]CallAllocation(nil), c.Allocations...)
+
+	for i := range updated.Allocations {
+		a := &updated.Allocations[i]
+		if a.PartnerID != partnerID {
+			continue
+		}
+
+		a.Payments = append(append([]CallPayment(nil), a.Payments...),
+			CallPayment{Amount: amount.Round(moneyPlaces), PaidAt: paidAt})
+
+		if err := validateCapitalCall(updated); err != nil {
+			return err
+		}
+
+		lastPaidAt := paidAt
+		for _, p := range a.Payments {
+			if p.PaidAt.After(lastPaidAt) {
+				lastPaidAt = p.PaidAt
+			}
+		}
+
+		s, err := updated.Statement(partnerID, lastPaidAt)
+		if err != nil {
+			return err
+		}
+		if s.Balance.IsNegative() {
+			return fmt.Errorf("%w: %s over", ErrPaymentOverBalance, s.Balance.Neg())
+		}
+
+		*c = updated
+		return nil
+	}
+
+	return ErrAllocationNotFound
+}
+
+// Statement is the position of a partner in a capital call on a date.
+type Statement struct {
+	PartnerID string
+	Principal decimal.Decimal
+	Penalty   decimal.Decimal
+	Interest  decimal.Decimal
+	Paid      decimal.Decimal
+	// PrincipalPaid is the part of the payments applied to the principal, the capital the
+	// partner actually contributed.
+	PrincipalPaid decimal.Decimal
+	Balance       decimal.Decimal
+	DaysLate      int
+	IsSettled     bool
+}
+
+// Statement applies the payments made until the date to the charges first, then to the
+// principal. Interest accrues on the principal left between payments.
+func (c CapitalCall) Statement(partnerID string, at time.Time) (Statement, error) {
+	a, ok := c.Allocation(partnerID)
+	if !ok {
+		return Statement{}, ErrAllocationNotFound
+	}
+
+	at = day(at)
+	graceEnd := c.DueDate.AddDate(0, 0, c.Policy.GraceDays)
+
+	payments := append([]CallPayment(nil), a.Payments...)
+	sort.SliceStable(payments, func(i, j int) bool { return payments[i].PaidAt.Before(payments[j].PaidAt) })
+
+	s := Statement{PartnerID: partnerID, Principal: a.Amount, Penalty: decimal.Zero, Interest: decimal.Zero,
+		Paid: decimal.Zero, PrincipalPaid: decimal.Zero}
+	principal, charges := a.Amount, decimal.Zero
+	accruedUntil := c.DueDate
+	penaltyCharged := false
+
+	// Until the grace period ends nothing accrues, so interest on the principal left unpaid
+	// then still counts from the due date.
+	accrue := func(until time.Time) {
+		if !until.After(graceEnd) || !principal.IsPositive() {
+			return
+		}
+
+		if !penaltyCharged {
+			penalty := principal.Mul(c.Policy.PenaltyPercent).Div(decimal.NewFromInt(100)).Round(moneyPlaces)
+			s.Penalty = s.Penalty.Add(penalty)
+			charges = charges.Add(penalty)
+			penaltyCharged = true
+		}
+
+		if days := int(until.Sub(accruedUntil).Hours() / 24); days > 0 {
+			interest := principal.Mul(c.Policy.MonthlyInterestPercent).Div(decimal.NewFromInt(100)).
+				Mul(decimal.NewFromInt(int64(days))).Div(decimal.NewFromInt(30)).Round(moneyPlaces)
+			s.Interest = s.Interest.Add(interest)
+			charges = charges.Add(interest)
+			accruedUntil = until
+		}
+	}
+
+	for _, p := range payments {
+		paidAt := day(p.PaidAt)
+		if paidAt.After(at) {
+			break
+		}
+
+		accrue(paidAt)
+
+		s.Paid = s.Paid.Add(p.Amount)
+		toCharges := decimal.Min(charges, p.Amount)
+		charges = charges.Sub(toCharges)
+		principal = principal.Sub(p.Amount.Sub(toCharges))
+		s.PrincipalPaid = s.PrincipalPaid.Add(p.Amount.Sub(toCharges))
+	}
+
+	accrue(at)
+
+	s.Balance = principal.Add(charges)
+	s.IsSettled = !s.Balance.IsPositive()
+	if !s.IsSettled && at.After(c.DueDate) {
+		s.DaysLate = int(at.Sub(c.DueDate).Hours() / 24)
+	}
+
+	return s, nil
+}
+
+// IsSettled tells whether every partner paid the call, with charges, by the date.
+func (c CapitalCall) IsSettled(at time.Time) bool {
+	for _, a := range c.Allocations {
+		s, err := c.Statement(a.PartnerID, at)
+		if err != nil || !s.IsSettled {
+			return false
+		}
+	}
+	return true
+}
+
+// CapitalCallNotice is sent to each investing partner when the call is issued.
+type CapitalCallNotice struct {
+	CallID         string
+	PartnerID      string
+	ConstructionID string
+	Purpose        string
+	CallAmount     decimal.Decimal
+	Percentage     decimal.Decimal
+	Amount         decimal.Decimal
+	DueDate        time.Time
+	Policy         LatePolicy
+}
+
+func (c CapitalCall) Notices() []CapitalCallNotice {
+	notices := make([]CapitalCallNotice, 0, len(c.Allocations))
+	for _, a := range c.Allocations {
+		notices = append(notices, CapitalCallNotice{
+			CallID:         c.ID,
+			PartnerID:      a.PartnerID,
+			ConstructionID: c.ConstructionID,
+			Purpose:        c.Purpose,
+			CallAmount:     c.Amount,
+			Percentage:     a.Percentage,
+			Amount:         a.Amount,
+			DueDate:        c.DueDate,
+			Policy:         c.Policy,
+		})
+	}
+	return notices
+}
+
+func (n CapitalCallNotice) Subject() string {
+	return fmt.Sprintf("Chamada de capital - vencimento %s", n.DueDate.Format("02/01/2006"))
+}
+
+func (n CapitalCallNotice) Body() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Chamada de capital de R$ %s para %s.\n", brl(n.CallAmount), n.Purpose)
+	fmt.Fprintf(&sb, "Sua participação de %s%% corresponde a R$ %s, com vencimento em %s.\n",
+		strings.ReplaceAll(n.Percentage.String(), ".", ","), brl(n.Amount), n.DueDate.Format("02/01/2006"))
+	if n.Policy.PenaltyPercent.IsPositive() || n.Policy.MonthlyInterestPercent.IsPositive() {
+		fmt.Fprintf(&sb, "Após %d dia(s) de tolerância incidem multa de %s%% e juros de %s%% ao mês.\n",
+			n.Policy.GraceDays, strings.ReplaceAll(n.Policy.PenaltyPercent.String(), ".", ","),
+			strings.ReplaceAll(n.Policy.MonthlyInterestPercent.String(), ".", ","))
+	}
+	return sb.String()
+}
+
+// brl formats an amount the Brazilian way, as in 1.234,56.
+func brl(amount decimal.Decimal) string {
+	fixed := amount.StringFixed(moneyPlaces)
+	sign := ""
+	if strings.HasPrefix(fixed, "-") {
+		sign, fixed = "-", fixed[1:]
+	}
+
+	intPart, fraction := fixed[:len(fixed)-3], fixed[len(fixed)-2:]
+	var groups []string
+	for len(intPart) > 3 {
+		groups = append([]string{intPart[len(intPart)-3:]}, groups...)
+		intPart = intPart[:len(intPart)-3]
+	}
+	groups = append([]string{intPart}, groups...)
+
+	return sign + strings.Join(groups, ".") + "," + fraction
+}
+
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func validateCapitalCall(c CapitalCall) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(c)
+	return err
+}
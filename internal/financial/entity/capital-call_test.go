@@ -0,0 +1,202 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newInvestments(t *testing.T, constructionID string, percentages ...string) []construction.Investment {
+	var investments []construction.Investment
+	for _, p := range percentages {
+		inv, err := construction.NewInvestment("", constructionID, uuid.New().String(), dec("100000"), dec(p), time.Time{})
+		require.Nil(t, err)
+		investments = append(investments, inv)
+	}
+	return investments
+}
+
+func TestCapitalCall_NewCapitalCall(t *testing.T) {
+	constructionID := uuid.New().String()
+	policy := entity.LatePolicy{PenaltyPercent: dec("2"), MonthlyInterestPercent: dec("1"), GraceDays: 5}
+	due := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		test        string
+		amount      string
+		investments []construction.Investment
+		policy      entity.LatePolicy
+		expectedErr string
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should require investing partners",
+			amount:      "1000",
+			investments: newInvestments(t, uuid.New().String(), "50"),
+			policy:      policy,
+			expectedErr: entity.ErrNoInvestors.Error(),
+		},
+		{
+			test:        "Should require a positive amount",
+			amount:      "0",
+			investments: newInvestments(t, constructionID, "50", "50"),
+			policy:      policy,
+			expectedErr: "invalid fields: CapitalCall.Amount: \"0\"",
+		},
+		{
+			test:        "Should reject a penalty over 100%",
+			amount:      "1000",
+			investments: newInvestments(t, constructionID, "50", "50"),
+			policy:      entity.LatePolicy{PenaltyPercent: dec("101")},
+			expectedErr: "invalid fields: CapitalCall.Policy.PenaltyPercent: \"101\"",
+		},
+		{
+			test:        "Should allocate the call to the investors",
+			amount:      "1000",
+			investments: newInvestments(t, constructionID, "50", "50"),
+			policy:      policy,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		call, err := entity.NewCapitalCall("", constructionID, "Concretagem das lajes", dec(tc.amount), due, tc.policy,
+			tc.investments, time.Time{})
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Len(t, call.Allocations, 2)
+	}
+}
+
+func TestCapitalCall_Statement(t *testing.T) {
+	constructionID := uuid.New().String()
+	investments := newInvestments(t, constructionID, "50", "30", "20")
+	a, b, c := investments[0].PartnerID, investments[1].PartnerID, investments[2].PartnerID
+
+	issued := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	policy := entity.LatePolicy{PenaltyPercent: dec("2"), MonthlyInterestPercent: dec("1"), GraceDays: 5}
+
+	call, err := entity.NewCapitalCall("", constructionID, "Concretagem das lajes", dec("100000.01"), due, policy,
+		investments, issued)
+	require.Nil(t, err)
+
+	allocation, _ := call.Allocation(a)
+	require.True(t, dec("50000.01").Equal(allocation.Amount))
+	allocation, _ = call.Allocation(b)
+	require.True(t, dec("30000").Equal(allocation.Amount))
+
+	notices := call.Notices()
+	require.Len(t, notices, 3)
+	require.Equal(t, "Chamada de capital - vencimento 10/03/2023", notices[0].Subject())
+	require.Contains(t, notices[0].Body(), "R$ 100.000,01")
+	require.Contains(t, notices[0].Body(), "50% corresponde a R$ 50.000,01")
+
+	require.Equal(t, entity.ErrPaymentBeforeIssuance, call.RegisterPayment(c, dec("20000"), issued.AddDate(0, 0, -1)))
+	require.Equal(t, entity.ErrAllocationNotFound, call.RegisterPayment(uuid.New().String(), dec("1"), due))
+	require.Nil(t, call.RegisterPayment(c, dec("20000"), time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC)))
+	require.Nil(t, call.RegisterPayment(b, dec("15000"), time.Date(2023, 3, 25, 0, 0, 0, 0, time.UTC)))
+
+	type testCase struct {
+		test             string
+		partnerID        string
+		at               time.Time
+		expectedPenalty  string
+		expectedInterest string
+		expectedBalance  string
+		expectedDaysLate int
+	}
+
+	testCases := []testCase{
+		{
+			test:             "Should not charge within the grace period",
+			partnerID:        a,
+			at:               time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC),
+			expectedPenalty:  "0",
+			expectedInterest: "0",
+			expectedBalance:  "50000.01",
+			expectedDaysLate: 4,
+		},
+		{
+			test:             "Should charge penalty and interest from the due date after the grace period",
+			partnerID:        a,
+			at:               time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC),
+			expectedPenalty:  "1000",
+			expectedInterest: "500",
+			expectedBalance:  "51500.01",
+			expectedDaysLate: 30,
+		},
+		{
+			test:             "Should settle a payment made within the grace period without charges",
+			partnerID:        c,
+			at:               time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC),
+			expectedPenalty:  "0",
+			expectedInterest: "0",
+			expectedBalance:  "0",
+		},
+		{
+			test:             "Should apply a late partial payment to the charges first",
+			partnerID:        b,
+			at:               time.Date(2023, 4, 24, 0, 0, 0, 0, time.UTC),
+			expectedPenalty:  "600",
+			expectedInterest: "307.5",
+			expectedBalance:  "15907.5",
+			expectedDaysLate: 45,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		s, err := call.Statement(tc.partnerID, tc.at)
+		require.Nil(t, err)
+		require.True(t, dec(tc.expectedPenalty).Equal(s.Penalty), s.Penalty.String())
+		require.True(t, dec(tc.expectedInterest).Equal(s.Interest), s.Interest.String())
+		require.True(t, dec(tc.expectedBalance).Equal(s.Balance), s.Balance.String())
+		require.Equal(t, tc.expectedDaysLate, s.DaysLate)
+	}
+
+	require.False(t, call.IsSettled(time.Date(2023, 4, 24, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCapitalCall_PaymentWithinGrace(t *testing.T) {
+	constructionID := uuid.New().String()
+	investments := newInvestments(t, constructionID, "100")
+	a := investments[0].PartnerID
+
+	issued := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	policy := entity.LatePolicy{PenaltyPercent: dec("2"), MonthlyInterestPercent: dec("1"), GraceDays: 5}
+
+	call, err := entity.NewCapitalCall("", constructionID, "Concretagem das lajes", dec("50000"), due, policy,
+		investments, issued)
+	require.Nil(t, err)
+
+	err = call.RegisterPayment(a, dec("50000.01"), time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC))
+	require.True(t, errors.Is(err, entity.ErrPaymentOverBalance))
+	require.Nil(t, call.RegisterPayment(a, dec("10000"), time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC)))
+
+	// The 40000 left accrue interest from the due date, not from the payment within the grace period.
+	s, err := call.Statement(a, time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC))
+	require.Nil(t, err)
+	require.True(t, dec("800").Equal(s.Penalty), s.Penalty.String())
+	require.True(t, dec("400").Equal(s.Interest), s.Interest.String())
+	require.True(t, dec("41200").Equal(s.Balance), s.Balance.String())
+
+	err = call.RegisterPayment(a, dec("41200.01"), time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC))
+	require.True(t, errors.Is(err, entity.ErrPaymentOverBalance))
+	require.Nil(t, call.RegisterPayment(a, dec("41200"), time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC)))
+	require.True(t, call.IsSettled(time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,236 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	partner "github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var ErrPartnerNotFound = errors.New("partner of the distribution not found")
+
+// DistributionLine is one step of the allocation of a distribution to an investor. Basis is
+// what the investor was owed by the tier, kept for auditing.
+type DistributionLine struct {
+	PartnerID    string          `validate:"required,uuid"`
+	InvestmentID string          `validate:"required,uuid"`
+	Tier         TierKind        `validate:"required,oneof=return_of_capital preferred_return pro_rata"`
+	Basis        decimal.Decimal `validate:"gte=0"`
+	Amount       decimal.Decimal `validate:"gte=0"`
+}
+
+// Distribution pays the proceeds of a construction, such as unit sales, to its investors
+// through the waterfall.
+type Distribution struct {
+	ID             string             `validate:"required,uuid"`
+	ConstructionID string             `validate:"required,uuid"`
+	Description    string             `validate:"required,min=3"`
+	Amount         decimal.Decimal    `validate:"gt=0"`
+	Date           time.Time          `validate:"required"`
+	Waterfall      Waterfall          `validate:""`
+	Lines          []DistributionLine `validate:"dive"`
+	CreatedAt      time.Time          `validate:"required"`
+}
+
+// NewDistribution allocates the amount to the investors of the construction. Their capital is
+// what they paid on their investments and on the capital calls. previous are the distributions
+// already made, which returned capital and paid preferred return.
+func NewDistribution(id string, constructionID string, description string, amount decimal.Decimal, date time.Time,
+	waterfall Waterfall, investments []construction.Investment, calls []CapitalCall, previous []Distribution,
+	createdAt time.Time) (Distribution, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	if err := waterfall.Validate(); err != nil {
+		return Distribution{}, err
+	}
+
+	var investors []construction.Investment
+	for _, inv := range investments {
+		if inv.ConstructionID == constructionID {
+			investors = append(investors, inv)
+		}
+	}
+
+	if len(investors) == 0 {
+		return Distribution{}, ErrNoInvestors
+	}
+
+	if err := construction.ValidateInvestors(constructionID, investors); err != nil {
+		return Distribution{}, err
+	}
+
+	d := Distribution{
+		ID:             id,
+		ConstructionID: constructionID,
+		Description:    description,
+		Amount:         amount.Round(moneyPlaces),
+		Date:           day(date),
+		Waterfall:      waterfall,
+		CreatedAt:      createdAt,
+	}
+
+	var history []Distribution
+	for _, p := range previous {
+		if p.ConstructionID == constructionID && !p.Date.After(d.Date) {
+			history = append(history, p)
+		}
+	}
+
+	available := d.Amount
+	for _, tier := range waterfall.Tiers {
+		if !available.IsPositive() {
+			break
+		}
+
+		positions := make([]investorPosition, len(investors))
+		for i, inv := range investors {
+			positions[i] = position(inv, d.Date, tier.AnnualRate, calls, append(append([]Distribution(nil), history...), d))
+		}
+
+		var owed func(p investorPosition) decimal.Decimal
+		switch tier.Kind {
+		case ReturnOfCapital:
+			owed = func(p investorPosition) decimal.Decimal { return p.unreturned }
+		case PreferredReturn:
+			owed = func(p investorPosition) decimal.Decimal { return p.prefOwed }
+		}
+
+		var parts []decimal.Decimal
+		var err error
+		if tier.Kind == ProRata {
+			shares := make([]Share, len(positions))
+			for i, p := range positions {
+				shares[i] = Share{Key: p.investment.PartnerID, Weight: p.investment.Percentage}
+			}
+			parts, err = AllocateProRata(available, shares)
+		} else {
+			parts, err = payTier(available, positions, owed)
+		}
+		if err != nil {
+			return Distribution{}, err
+		}
+
+		for i, part := range parts {
+			if !part.IsPositive() {
+				continue
+			}
+
+			// What is left is owed by stake, so the pro rata tier owes exactly its part.
+			basis := part
+			if owed != nil {
+				basis = owed(positions[i])
+			}
+
+			d.Lines = append(d.Lines, DistributionLine{
+				PartnerID:    positions[i].investment.PartnerID,
+				InvestmentID: positions[i].investment.ID,
+				Tier:         tier.Kind,
+				Basis:        basis,
+				Amount:       part,
+			})
+			available = available.Sub(part)
+		}
+	}
+
+	return d, validateDistribution(d)
+}
+
+// PartnerTotal is what the partner receives from the distribution, all tiers included.
+func (d Distribution) PartnerTotal(partnerID string) decimal.Decimal {
+	total := decimal.Zero
+	for _, l := range d.Lines {
+		if l.PartnerID == partnerID {
+			total = total.Add(l.Amount)
+		}
+	}
+	return total
+}
+
+func (d Distribution) Partners() []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, l := range d.Lines {
+		if !seen[l.PartnerID] {
+			seen[l.PartnerID] = true
+			ids = append(ids, l.PartnerID)
+		}
+	}
+	return ids
+}
+
+// PaymentInstruction orders the transfer of a partner share to the partner default bank account.
+type PaymentInstruction struct {
+	DistributionID string
+	PartnerID      string
+	Amount         decimal.Decimal
+	Account        valueobjects.BankAccount
+	Description    string
+}
+
+// PaymentInstructions fails if any partner of the distribution has no default bank account, so
+// a distribution is never paid in part.
+func (d Distribution) PaymentInstructions(partners []partner.Partner) ([]PaymentInstruction, error) {
+	byID := make(map[string]partner.Partner, len(partners))
+	for _, p := range partners {
+		byID[p.ID] = p
+	}
+
+	var instructions []PaymentInstruction
+	for _, id := range d.Partners() {
+		p, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrPartnerNotFound, id)
+		}
+
+		account, err := p.DefaultBankAccount()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, id)
+		}
+
+		instructions = append(instructions, PaymentInstruction{
+			DistributionID: d.ID,
+			PartnerID:      id,
+			Amount:         d.PartnerTotal(id),
+			Account:        account.Account,
+			Description:    d.describe(id),
+		})
+	}
+
+	return instructions, nil
+}
+
+func (d Distribution) describe(partnerID string) string {
+	names := map[TierKind]string{
+		ReturnOfCapital: "devolução de capital",
+		PreferredReturn: "retorno preferencial",
+		ProRata:         "lucro",
+	}
+
+	var parts []string
+	for _, l := range d.Lines {
+		if l.PartnerID == partnerID {
+			parts = append(parts, fmt.Sprintf("%s R$ %s", names[l.Tier], brl(l.Amount)))
+		}
+	}
+	return d.Description + ": " + strings.Join(parts, ", ")
+}
+
+func validateDistribution(d Distribution) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(d)
+	return err
+}
@@ -0,0 +1,182 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/entity"
+	partner "github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newInvestor(t *testing.T, constructionID string, partnerID string, percentage string, contribution string,
+	paidAt time.Time) construction.Investment {
+
+	inv, err := construction.NewInvestment("", constructionID, partnerID, dec(contribution), dec(percentage), time.Time{})
+	require.Nil(t, err)
+	c, err := inv.AddContribution(dec(contribution), paidAt)
+	require.Nil(t, err)
+	require.Nil(t, inv.PayContribution(c.ID, paidAt))
+	return inv
+}
+
+func TestWaterfall_Validate(t *testing.T) {
+	type testCase struct {
+		test        string
+		waterfall   entity.Waterfall
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should require tiers",
+			waterfall:   entity.Waterfall{},
+			expectedErr: errors.New("invalid fields: Waterfall.Tiers: \"[]\""),
+		},
+		{
+			test:        "Should require the preferred return rate",
+			waterfall:   entity.Waterfall{Tiers: []entity.Tier{{Kind: entity.PreferredReturn}, {Kind: entity.ProRata}}},
+			expectedErr: errors.New("invalid fields: Waterfall.Tiers[0].AnnualRate: \"0\""),
+		},
+		{
+			test:        "Should end with a pro rata tier",
+			waterfall:   entity.Waterfall{Tiers: []entity.Tier{{Kind: entity.ReturnOfCapital}}},
+			expectedErr: entity.ErrWaterfallWithoutProRata,
+		},
+		{
+			test:      "Should accept the default waterfall",
+			waterfall: entity.DefaultWaterfall(dec("8")),
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		require.Equal(t, tc.expectedErr, tc.waterfall.Validate())
+	}
+}
+
+func TestDistribution_NewDistribution(t *testing.T) {
+	constructionID := uuid.New().String()
+
+	account := valueobjects.BankAccount{
+		BankCode: "341", Branch: "0123", Number: "45678", CheckDigit: "9", Type: valueobjects.CheckingAccount,
+		HolderName: "John Doe", HolderTaxID: "52998224725",
+	}
+	john, err := partner.NewPartnerIdentity("", "John", "Doe", "529.982.247-25", time.Time{})
+	require.Nil(t, err)
+	_, err = john.AddBankAccount(account, true)
+	require.Nil(t, err)
+	jane, err := partner.NewPartnerIdentity("", "Jane", "Roe", "111.444.777-35", time.Time{})
+	require.Nil(t, err)
+
+	investments := []construction.Investment{
+		newInvestor(t, constructionID, john.ID, "60", "60000", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+		newInvestor(t, constructionID, jane.ID, "40", "40000", time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	waterfall := entity.DefaultWaterfall(dec("10"))
+
+	_, err = entity.NewDistribution("", uuid.New().String(), "Vendas", dec("1000"), time.Time{}, waterfall,
+		investments, nil, nil, time.Time{})
+	require.Equal(t, entity.ErrNoInvestors, err)
+
+	first, err := entity.NewDistribution("", constructionID, "Vendas do 1º semestre", dec("50000"),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), waterfall, investments, nil, nil, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, first.Lines, 2)
+	require.Equal(t, entity.ReturnOfCapital, first.Lines[0].Tier)
+	require.True(t, dec("30000").Equal(first.PartnerTotal(john.ID)))
+	require.True(t, dec("20000").Equal(first.PartnerTotal(jane.ID)))
+
+	second, err := entity.NewDistribution("", constructionID, "Vendas do 2º semestre", dec("100000"),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), waterfall, investments, nil, []entity.Distribution{first}, time.Time{})
+	require.Nil(t, err)
+
+	type expectedLine struct {
+		partnerID string
+		tier      entity.TierKind
+		basis     string
+		amount    string
+	}
+
+	expected := []expectedLine{
+		{john.ID, entity.ReturnOfCapital, "30000", "30000"},
+		{jane.ID, entity.ReturnOfCapital, "20000", "20000"},
+		{john.ID, entity.PreferredReturn, "9000", "9000"},
+		{jane.ID, entity.PreferredReturn, "4016.44", "4016.44"},
+		{john.ID, entity.ProRata, "22190.14", "22190.14"},
+		{jane.ID, entity.ProRata, "14793.42", "14793.42"},
+	}
+
+	require.Len(t, second.Lines, len(expected))
+	for i, e := range expected {
+		l := second.Lines[i]
+		require.Equal(t, e.partnerID, l.PartnerID)
+		require.Equal(t, e.tier, l.Tier)
+		require.True(t, dec(e.basis).Equal(l.Basis), "basis %s", l.Basis)
+		require.True(t, dec(e.amount).Equal(l.Amount), "amount %s", l.Amount)
+	}
+
+	_, err = second.PaymentInstructions([]partner.Partner{john, jane})
+	require.True(t, errors.Is(err, partner.ErrNoDefaultBankAccount))
+
+	_, err = second.PaymentInstructions([]partner.Partner{john})
+	require.True(t, errors.Is(err, entity.ErrPartnerNotFound))
+
+	janeAccount := account
+	janeAccount.HolderName, janeAccount.HolderTaxID = "Jane Roe", "11144477735"
+	_, err = jane.AddBankAccount(janeAccount, true)
+	require.Nil(t, err)
+
+	instructions, err := second.PaymentInstructions([]partner.Partner{john, jane})
+	require.Nil(t, err)
+	require.Len(t, instructions, 2)
+	require.True(t, dec("61190.14").Equal(instructions[0].Amount))
+	require.Equal(t, "52998224725", instructions[0].Account.HolderTaxID)
+	require.Equal(t, "Vendas do 2º semestre: devolução de capital R$ 20.000,00, retorno preferencial R$ 4.016,44, "+
+		"lucro R$ 14.793,42", instructions[1].Description)
+}
+
+func TestDistribution_NewDistributionReturnsCalledCapital(t *testing.T) {
+	constructionID := uuid.New().String()
+	investments := newInvestments(t, constructionID, "50", "50")
+	onTime, late := investments[0].PartnerID, investments[1].PartnerID
+
+	policy := entity.LatePolicy{PenaltyPercent: dec("2"), MonthlyInterestPercent: dec("1")}
+	call, err := entity.NewCapitalCall("", constructionID, "Fundação", dec("100000"),
+		time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC), policy, investments, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Nil(t, err)
+
+	require.Nil(t, call.RegisterPayment(onTime, dec("50000"), time.Date(2022, 1, 5, 0, 0, 0, 0, time.UTC)))
+
+	paidAt := time.Date(2022, 3, 11, 0, 0, 0, 0, time.UTC)
+	s, err := call.Statement(late, paidAt)
+	require.Nil(t, err)
+	require.True(t, s.Balance.GreaterThan(dec("50000")))
+	require.Nil(t, call.RegisterPayment(late, s.Balance, paidAt))
+
+	waterfall := entity.Waterfall{Tiers: []entity.Tier{{Kind: entity.ReturnOfCapital}, {Kind: entity.ProRata}}}
+	d, err := entity.NewDistribution("", constructionID, "Vendas", dec("110000"),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), waterfall, investments, []entity.CapitalCall{call}, nil,
+		time.Time{})
+	require.Nil(t, err)
+
+	require.Len(t, d.Lines, 4)
+	for _, l := range d.Lines[:2] {
+		require.Equal(t, entity.ReturnOfCapital, l.Tier)
+		require.True(t, dec("50000").Equal(l.Basis), "basis %s", l.Basis)
+	}
+	require.True(t, dec("55000").Equal(d.PartnerTotal(onTime)))
+	require.True(t, dec("55000").Equal(d.PartnerTotal(late)))
+
+	early, err := entity.NewDistribution("", constructionID, "Vendas", dec("110000"),
+		time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), waterfall, investments, []entity.CapitalCall{call}, nil,
+		time.Time{})
+	require.Nil(t, err)
+	require.True(t, dec("80000").Equal(early.PartnerTotal(onTime)))
+	require.True(t, dec("30000").Equal(early.PartnerTotal(late)))
+}
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	supplier "github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ExpenditureStatus string
+
+const (
+	ExpenditureCommitted ExpenditureStatus = "committed"
+	ExpenditurePaid      ExpenditureStatus = "paid"
+	ExpenditureCancelled ExpenditureStatus = "cancelled"
+)
+
+var (
+	ErrExpenditureNotCommitted = errors.New("only committed expenditures can be paid or cancelled")
+	ErrPaymentBeforeCommitment = errors.New("payment date is before the commitment date")
+)
+
+// Expenditure is a cost of a construction, tagged with the budget item it consumes. It is
+// committed when the purchase order is issued and spent when it is paid.
+type Expenditure struct {
+	ID             string            `validate:"required,uuid"`
+	CompanyID      string            `validate:"required,uuid"`
+	ConstructionID string            `validate:"required,uuid"`
+	BudgetItemID   string            `validate:"required,uuid"`
+	SupplierID     string            `validate:"omitempty,uuid"`
+	Description    string            `validate:"required,min=3"`
+	PurchaseOrder  string            `validate:"-"`
+	Amount         decimal.Decimal   `validate:"gt=0"`
+	Status         ExpenditureStatus `validate:"required,oneof=committed paid cancelled"`
+	CommittedAt    time.Time         `validate:"required"`
+	PaidAt         time.Time         `validate:"required_if=Status paid,omitempty,gtefield=CommittedAt"`
+	CreatedAt      time.Time         `validate:"required"`
+}
+
+func NewExpenditure(id string, companyID string, constructionID string, budgetItemID string, description string,
+	purchaseOrder string, amount decimal.Decimal, committedAt time.Time, createdAt time.Time) (Expenditure, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	if committedAt.IsZero() {
+		committedAt = createdAt
+	}
+
+	e := Expenditure{
+		ID:             id,
+		CompanyID:      companyID,
+		ConstructionID: constructionID,
+		BudgetItemID:   budgetItemID,
+		Description:    description,
+		PurchaseOrder:  purchaseOrder,
+		Amount:         amount.Round(2),
+		Status:         ExpenditureCommitted,
+		CommittedAt:    committedAt,
+		CreatedAt:      createdAt,
+	}
+
+	return e, validateExpenditure(e)
+}
+
+func (e *Expenditure) Pay(paidAt time.Time) error {
+	if e.Status != ExpenditureCommitted {
+		return ErrExpenditureNotCommitted
+	}
+
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	if paidAt.Before(e.CommittedAt) {
+		return ErrPaymentBeforeCommitment
+	}
+
+	e.Status = ExpenditurePaid
+	e.PaidAt = paidAt
+	return nil
+}
+
+// AssignSupplier sets the counterparty of a committed expenditure. Blocked suppliers cannot be
+// assigned.
+func (e *Expenditure) AssignSupplier(s supplier.Supplier) error {
+	if e.Status != ExpenditureCommitted {
+		return ErrExpenditureNotCommitted
+	}
+
+	if s.Blocked {
+		return fmt.Errorf("%w: %s", supplier.ErrSupplierBlocked, s.BlockReason)
+	}
+
+	updated := *e
+	updated.SupplierID = s.ID
+	if err := validateExpenditure(updated); err != nil {
+		return err
+	}
+
+	*e = updated
+	return nil
+}
+
+func (e *Expenditure) Cancel() error {
+	if e.Status != ExpenditureCommitted {
+		return ErrExpenditureNotCommitted
+	}
+
+	e.Status = ExpenditureCancelled
+	return nil
+}
+
+func validateExpenditure(e Expenditure) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(e)
+	return err
+}
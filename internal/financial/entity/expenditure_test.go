@@ -0,0 +1,99 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/entity"
+	supplier "github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpenditure_NewExpenditure(t *testing.T) {
+	type testCase struct {
+		test           string
+		constructionID string
+		budgetItemID   string
+		description    string
+		amount         decimal.Decimal
+		expectedErr    string
+	}
+
+	testCases := []testCase{
+		{
+			test:           "Should require a positive amount",
+			constructionID: uuid.New().String(),
+			budgetItemID:   uuid.New().String(),
+			description:    "Concreto usinado",
+			amount:         decimal.Zero,
+			expectedErr:    "invalid fields: Expenditure.Amount: \"0\"",
+		},
+		{
+			test:           "Should require the budget item",
+			constructionID: uuid.New().String(),
+			description:    "Concreto usinado",
+			amount:         decimal.NewFromInt(10),
+			expectedErr:    "invalid fields: Expenditure.BudgetItemID: \"\"",
+		},
+		{
+			test:           "Should create a committed expenditure",
+			constructionID: uuid.New().String(),
+			budgetItemID:   uuid.New().String(),
+			description:    "Concreto usinado",
+			amount:         decimal.RequireFromString("5123.456"),
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		e, err := entity.NewExpenditure("", uuid.New().String(), tc.constructionID, tc.budgetItemID, tc.description,
+			"PO-1", tc.amount, time.Time{}, time.Time{})
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, entity.ExpenditureCommitted, e.Status)
+		require.True(t, decimal.RequireFromString("5123.46").Equal(e.Amount))
+	}
+}
+
+func TestExpenditure_Pay(t *testing.T) {
+	committedAt := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	e, err := entity.NewExpenditure("", uuid.New().String(), uuid.New().String(), uuid.New().String(), "Aço CA-50",
+		"", decimal.NewFromInt(1000), committedAt, time.Time{})
+	require.Nil(t, err)
+
+	require.Equal(t, entity.ErrPaymentBeforeCommitment, e.Pay(committedAt.AddDate(0, 0, -1)))
+	require.Nil(t, e.Pay(committedAt.AddDate(0, 0, 30)))
+	require.Equal(t, entity.ExpenditurePaid, e.Status)
+	require.Equal(t, entity.ErrExpenditureNotCommitted, e.Pay(time.Time{}))
+	require.Equal(t, entity.ErrExpenditureNotCommitted, e.Cancel())
+}
+
+func TestExpenditure_AssignSupplier(t *testing.T) {
+	e, err := entity.NewExpenditure("", uuid.New().String(), uuid.New().String(), uuid.New().String(), "Concreto usinado",
+		"OC-0042", decimal.NewFromInt(1000), time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	concreteSupplier, err := supplier.NewSupplier("", "Concreteira Paulista Ltda", "", "11.222.333/0001-81",
+		[]supplier.TradeCategory{supplier.TradeConcrete}, time.Time{})
+	require.Nil(t, err)
+
+	blocked := concreteSupplier
+	require.Nil(t, blocked.Block("Certidão negativa vencida", time.Time{}))
+	require.True(t, errors.Is(e.AssignSupplier(blocked), supplier.ErrSupplierBlocked))
+	require.Empty(t, e.SupplierID)
+
+	require.Nil(t, e.AssignSupplier(concreteSupplier))
+	require.Equal(t, concreteSupplier.ID, e.SupplierID)
+
+	require.Nil(t, e.Cancel())
+	require.Equal(t, entity.ErrExpenditureNotCommitted, e.AssignSupplier(concreteSupplier))
+}
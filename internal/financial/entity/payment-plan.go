@@ -0,0 +1,388 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/calendar"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type InstallmentKind string
+
+const (
+	DownPayment InstallmentKind = "down_payment"
+	Monthly     InstallmentKind = "monthly"
+	Balloon     InstallmentKind = "balloon"
+	Keys        InstallmentKind = "keys"
+)
+
+type InstallmentStatus string
+
+const (
+	InstallmentPending    InstallmentStatus = "pending"
+	InstallmentPaid       InstallmentStatus = "paid"
+	InstallmentDischarged InstallmentStatus = "discharged"
+)
+
+var (
+	ErrPlanDoesNotMatchPrice  = errors.New("payment plan installments do not add up to the sale price")
+	ErrInstallmentNotFound    = errors.New("installment not found")
+	ErrInstallmentNotPending  = errors.New("installment is not pending")
+	ErrInsufficientPayment    = errors.New("payment is less than the corrected installment amount")
+	ErrPlanSettled            = errors.New("payment plan has no pending installments")
+	ErrPayoffQuoteOutdated    = errors.New("payoff quote does not match the pending installments")
+	ErrPayoffQuoteExpired     = errors.New("payoff quote was issued for another day")
+	ErrWrongCorrectionIndexes = errors.New("payment plans are corrected by INCC and IGP-M")
+)
+
+// PlanTerm generates Count installments of Amount, at base month prices, every IntervalMonths
+// from FirstDueDate.
+type PlanTerm struct {
+	Kind           InstallmentKind `validate:"required,oneof=down_payment monthly balloon keys"`
+	Count          int             `validate:"gte=1"`
+	Amount         decimal.Decimal `validate:"gt=0"`
+	FirstDueDate   time.Time       `validate:"required"`
+	IntervalMonths int             `validate:"gte=0"`
+}
+
+type Installment struct {
+	ID         string            `validate:"required,uuid"`
+	Number     int               `validate:"gte=1"`
+	Kind       InstallmentKind   `validate:"required,oneof=down_payment monthly balloon keys"`
+	DueDate    time.Time         `validate:"required"`
+	BaseAmount decimal.Decimal   `validate:"gt=0"`
+	Status     InstallmentStatus `validate:"required,oneof=pending paid discharged"`
+	PaidAmount decimal.Decimal   `validate:"required_if=Status paid,gte=0"`
+	PaidAt     time.Time         `validate:"required_unless=Status pending"`
+}
+
+// Indexes correct the installments: INCC until the delivery of the construction, IGP-M after.
+type Indexes struct {
+	INCC *index.Series
+	IGPM *index.Series
+}
+
+type Payoff struct {
+	Amount   decimal.Decimal `validate:"gt=0"`
+	Discount decimal.Decimal `validate:"gte=0"`
+	PaidAt   time.Time       `validate:"required"`
+}
+
+// PaymentPlan is how the buyer pays a unit sale. Installment amounts are set at the prices of
+// the base month and corrected monthly up to their due date.
+type PaymentPlan struct {
+	ID           string        `validate:"required,uuid"`
+	SaleID       string        `validate:"required,uuid"`
+	BaseMonth    time.Time     `validate:"required"`
+	DeliveryDate time.Time     `validate:"required"`
+	Installments []Installment `validate:"required,min=1,dive"`
+	Payoff       *Payoff       `validate:"omitempty"`
+	CreatedAt    time.Time     `validate:"required"`
+}
+
+// NewPaymentPlan generates the installments of the terms and attaches the plan to the sale.
+func NewPaymentPlan(id string, sale *construction.Sale, baseMonth time.Time, deliveryDate time.Time, terms []PlanTerm,
+	createdAt time.Time) (PaymentPlan, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	cv := validator.NewCustomValidate()
+	for _, term := range terms {
+		if err := cv.Validate(term); err != nil {
+			return PaymentPlan{}, err
+		}
+	}
+
+	plan := PaymentPlan{
+		ID:           id,
+		SaleID:       sale.ID,
+		BaseMonth:    firstOfMonth(baseMonth),
+		DeliveryDate: day(deliveryDate),
+		CreatedAt:    createdAt,
+	}
+
+	total := decimal.Zero
+	for _, term := range terms {
+		for i := 0; i < term.Count; i++ {
+			plan.Installments = append(plan.Installments, Installment{
+				ID:         uuid.New().String(),
+				Number:     len(plan.Installments) + 1,
+				Kind:       term.Kind,
+				DueDate:    calendar.AddMonths(day(term.FirstDueDate), i*term.IntervalMonths),
+				BaseAmount: term.Amount.Round(moneyPlaces),
+				Status:     InstallmentPending,
+			})
+			total = total.Add(term.Amount.Round(moneyPlaces))
+		}
+	}
+
+	if err := validatePaymentPlan(plan); err != nil {
+		return PaymentPlan{}, err
+	}
+
+	if !total.Equal(sale.Price) {
+		return PaymentPlan{}, fmt.Errorf("%w: %s of %s", ErrPlanDoesNotMatchPrice, total, sale.Price)
+	}
+
+	if err := sale.AttachPaymentPlan(plan.ID); err != nil {
+		return PaymentPlan{}, err
+	}
+
+	return plan, nil
+}
+
+func (p PaymentPlan) Installment(installmentID string) (Installment, bool) {
+	for _, i := range p.Installments {
+		if i.ID == installmentID {
+			return i, true
+		}
+	}
+	return Installment{}, false
+}
+
+// CorrectionFactor is the index correction from the base month to the month of the date,
+// INCC until delivery and IGP-M from the delivery on.
+func (p PaymentPlan) CorrectionFactor(at time.Time, idx Indexes) (decimal.Decimal, error) {
+	if idx.INCC == nil || idx.IGPM == nil || idx.INCC.Name() != index.INCC || idx.IGPM.Name() != index.IGPM {
+		return decimal.Zero, ErrWrongCorrectionIndexes
+	}
+
+	inccUntil := at
+	if at.After(p.DeliveryDate) {
+		inccUntil = p.DeliveryDate
+	}
+
+	factor, err := idx.INCC.Factor(p.BaseMonth, inccUntil)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if at.After(p.DeliveryDate) {
+		igpm, err := idx.IGPM.Factor(p.DeliveryDate, at)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		factor = factor.Mul(igpm)
+	}
+
+	return factor, nil
+}
+
+// AmountDue is the installment corrected up to its due date, or up to the date if it is not due yet.
+func (p PaymentPlan) AmountDue(installmentID string, at time.Time, idx Indexes) (decimal.Decimal, error) {
+	i, ok := p.Installment(installmentID)
+	if !ok {
+		return decimal.Zero, ErrInstallmentNotFound
+	}
+	return p.correct(i, at, idx)
+}
+
+func (p PaymentPlan) correct(i Installment, at time.Time, idx Indexes) (decimal.Decimal, error) {
+	until := i.DueDate
+	if at.Before(until) {
+		until = at
+	}
+
+	factor, err := p.CorrectionFactor(until, idx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return i.BaseAmount.Mul(factor).Round(moneyPlaces), nil
+}
+
+func (p *PaymentPlan) PayInstallment(installmentID string, amount decimal.Decimal, paidAt time.Time, idx Indexes) error {
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	updated := p.clone()
+	for n := range updated.Installments {
+		i := &updated.Installments[n]
+		if i.ID != installmentID {
+			continue
+		}
+
+		if i.Status != InstallmentPending {
+			return ErrInstallmentNotPending
+		}
+
+		due, err := p.correct(*i, paidAt, idx)
+		if err != nil {
+			return err
+		}
+
+		if amount.LessThan(due) {
+			return fmt.Errorf("%w: %s of %s", ErrInsufficientPayment, amount, due)
+		}
+
+		i.Status = InstallmentPaid
+		i.PaidAmount = amount.Round(moneyPlaces)
+		i.PaidAt = paidAt
+
+		if err := validatePaymentPlan(updated); err != nil {
+			return err
+		}
+
+		*p = updated
+		return nil
+	}
+
+	return ErrInstallmentNotFound
+}
+
+// OutstandingBalance sums the pending installments, corrected up to the date.
+func (p PaymentPlan) OutstandingBalance(at time.Time, idx Indexes) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, i := range p.Installments {
+		if i.Status != InstallmentPending {
+			continue
+		}
+
+		amount, err := p.correct(i, at, idx)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		total = total.Add(amount)
+	}
+	return total, nil
+}
+
+// PayoffQuote is the amount to settle every pending installment on a date.
+type PayoffQuote struct {
+	Date         time.Time
+	Balance      decimal.Decimal
+	Discount     decimal.Decimal
+	Amount       decimal.Decimal
+	Installments []string
+}
+
+// QuotePayoff discounts the installments not due yet to the date, at the monthly rate compounded
+// for each full month before their due date. Installments already due are paid in full.
+func (p PaymentPlan) QuotePayoff(at time.Time, monthlyDiscountPercent decimal.Decimal, idx Indexes) (PayoffQuote, error) {
+	at = day(at)
+	q := PayoffQuote{Date: at, Balance: decimal.Zero, Discount: decimal.Zero, Amount: decimal.Zero}
+
+	rate := decimal.NewFromInt(1).Add(monthlyDiscountPercent.Div(decimal.NewFromInt(100)))
+	for _, i := range p.Installments {
+		if i.Status != InstallmentPending {
+			continue
+		}
+
+		amount, err := p.correct(i, at, idx)
+		if err != nil {
+			return PayoffQuote{}, err
+		}
+
+		present := amount
+		if months := fullMonths(at, i.DueDate); months > 0 {
+			present = amount.Div(rate.Pow(decimal.NewFromInt(int64(months)))).Round(moneyPlaces)
+		}
+
+		q.Balance = q.Balance.Add(amount)
+		q.Amount = q.Amount.Add(present)
+		q.Installments = append(q.Installments, i.ID)
+	}
+
+	if len(q.Installments) == 0 {
+		return PayoffQuote{}, ErrPlanSettled
+	}
+
+	q.Discount = q.Balance.Sub(q.Amount)
+	return q, nil
+}
+
+// Settle discharges the pending installments with an early payoff. The quote is only valid on
+// the day it was issued, as its discount depends on that date.
+func (p *PaymentPlan) Settle(q PayoffQuote, paidAt time.Time) error {
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	if !q.Date.Equal(day(paidAt)) {
+		return ErrPayoffQuoteExpired
+	}
+
+	pending := make(map[string]bool)
+	for _, i := range p.Installments {
+		if i.Status == InstallmentPending {
+			pending[i.ID] = true
+		}
+	}
+
+	if len(pending) != len(q.Installments) {
+		return ErrPayoffQuoteOutdated
+	}
+	for _, id := range q.Installments {
+		if !pending[id] {
+			return ErrPayoffQuoteOutdated
+		}
+	}
+
+	updated := p.clone()
+	shares := make([]Share, 0, len(q.Installments))
+	for _, i := range updated.Installments {
+		if pending[i.ID] {
+			shares = append(shares, Share{Key: i.ID, Weight: i.BaseAmount})
+		}
+	}
+
+	parts, err := AllocateProRata(q.Amount, shares)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for k := range updated.Installments {
+		i := &updated.Installments[k]
+		if !pending[i.ID] {
+			continue
+		}
+		i.Status = InstallmentDischarged
+		i.PaidAmount = parts[n]
+		i.PaidAt = paidAt
+		n++
+	}
+	updated.Payoff = &Payoff{Amount: q.Amount, Discount: q.Discount, PaidAt: paidAt}
+
+	if err := validatePaymentPlan(updated); err != nil {
+		return err
+	}
+
+	*p = updated
+	return nil
+}
+
+func (p PaymentPlan) clone() PaymentPlan {
+	p.Installments = append([]Installment(nil), p.Installments...)
+	return p
+}
+
+// fullMonths counts the whole months from from to to.
+func fullMonths(from time.Time, to time.Time) int {
+	months := 0
+	for !calendar.AddMonths(from, months+1).After(to) {
+		months++
+	}
+	return months
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func validatePaymentPlan(p PaymentPlan) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(p)
+	return err
+}
@@ -0,0 +1,204 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func newSale(t *testing.T, price string) construction.Sale {
+	constructionID := uuid.New().String()
+	unit, err := construction.NewUnit("", constructionID, "101", construction.Apartment, dec("80"), dec("20"),
+		dec("0.02"), dec(price), time.Time{})
+	require.Nil(t, err)
+	table, err := construction.NewPriceTable("", constructionID, date(2023, time.January, 1), []construction.Unit{unit},
+		time.Time{})
+	require.Nil(t, err)
+	buyer, err := construction.NewBuyer("", "Maria Silva", "529.982.247-25", "", "", time.Time{})
+	require.Nil(t, err)
+
+	sale, err := construction.SellUnit(&unit, buyer, table, index.NewSeries(index.INCC), dec("0"), "",
+		date(2023, time.January, 10), time.Time{})
+	require.Nil(t, err)
+	return sale
+}
+
+func newIndexes(t *testing.T) entity.Indexes {
+	idx := entity.Indexes{INCC: index.NewSeries(index.INCC), IGPM: index.NewSeries(index.IGPM)}
+	var incc, igpm []index.Value
+	for m := time.January; m <= time.December; m++ {
+		incc = append(incc, index.Value{Date: date(2023, m, 1), Percent: dec("1")})
+		igpm = append(igpm, index.Value{Date: date(2023, m, 1), Percent: dec("0.5")})
+	}
+	_, err := idx.INCC.Import(incc, "FGV IBRE", time.Time{})
+	require.Nil(t, err)
+	_, err = idx.IGPM.Import(igpm, "FGV IBRE", time.Time{})
+	require.Nil(t, err)
+	return idx
+}
+
+var planTerms = []entity.PlanTerm{
+	{Kind: entity.DownPayment, Count: 1, Amount: dec("30000"), FirstDueDate: date(2023, time.January, 15)},
+	{Kind: entity.Monthly, Count: 6, Amount: dec("20000"), FirstDueDate: date(2023, time.February, 15), IntervalMonths: 1},
+	{Kind: entity.Keys, Count: 1, Amount: dec("150000"), FirstDueDate: date(2023, time.July, 15)},
+}
+
+func TestPaymentPlan_NewPaymentPlan(t *testing.T) {
+	type testCase struct {
+		test        string
+		price       string
+		terms       []entity.PlanTerm
+		expectedErr string
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should add up to the sale price",
+			price:       "310000",
+			terms:       planTerms,
+			expectedErr: "payment plan installments do not add up to the sale price: 300000 of 310000",
+		},
+		{
+			test:        "Should validate the terms",
+			price:       "300000",
+			terms:       []entity.PlanTerm{{Kind: "weekly", Count: 1, Amount: dec("300000"), FirstDueDate: date(2023, 1, 15)}},
+			expectedErr: "invalid fields: PlanTerm.Kind: \"weekly\"",
+		},
+		{
+			test:  "Should generate the installments",
+			price: "300000",
+			terms: planTerms,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		sale := newSale(t, tc.price)
+		plan, err := entity.NewPaymentPlan("", &sale, date(2023, time.January, 10), date(2023, time.June, 30), tc.terms,
+			time.Time{})
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+			require.Empty(t, sale.PaymentPlanID)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, plan.ID, sale.PaymentPlanID)
+		require.Len(t, plan.Installments, 8)
+		require.Equal(t, date(2023, time.July, 15), plan.Installments[6].DueDate)
+		require.Equal(t, entity.Keys, plan.Installments[7].Kind)
+	}
+
+	sale := newSale(t, "40000")
+	plan, err := entity.NewPaymentPlan("", &sale, date(2023, time.January, 1), date(2023, time.June, 30),
+		[]entity.PlanTerm{{Kind: entity.Monthly, Count: 2, Amount: dec("20000"), FirstDueDate: date(2023, 1, 31), IntervalMonths: 1}},
+		time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, date(2023, time.February, 28), plan.Installments[1].DueDate)
+}
+
+func TestPaymentPlan_Correction(t *testing.T) {
+	sale := newSale(t, "300000")
+	plan, err := entity.NewPaymentPlan("", &sale, date(2023, time.January, 10), date(2023, time.June, 30), planTerms,
+		time.Time{})
+	require.Nil(t, err)
+	idx := newIndexes(t)
+
+	_, err = plan.CorrectionFactor(date(2023, time.March, 1), entity.Indexes{INCC: idx.IGPM, IGPM: idx.IGPM})
+	require.Equal(t, entity.ErrWrongCorrectionIndexes, err)
+
+	keys, err := plan.AmountDue(plan.Installments[7].ID, date(2023, time.August, 1), idx)
+	require.Nil(t, err)
+	require.True(t, dec("158439.77").Equal(keys), keys.String())
+
+	_, err = plan.AmountDue(plan.Installments[7].ID, date(2024, time.February, 1), entity.Indexes{
+		INCC: idx.INCC, IGPM: index.NewSeries(index.IGPM),
+	})
+	require.True(t, errors.Is(err, index.ErrMissingValue))
+
+	down := plan.Installments[0].ID
+	require.True(t, errors.Is(plan.PayInstallment(down, dec("29999.99"), date(2023, time.January, 15), idx),
+		entity.ErrInsufficientPayment))
+	require.Nil(t, plan.PayInstallment(down, dec("30000"), date(2023, time.January, 15), idx))
+	require.Equal(t, entity.ErrInstallmentNotPending, plan.PayInstallment(down, dec("30000"), date(2023, 1, 15), idx))
+
+	third, err := plan.AmountDue(plan.Installments[2].ID, date(2023, time.March, 15), idx)
+	require.Nil(t, err)
+	require.True(t, dec("20402").Equal(third), third.String())
+
+	balance, err := plan.OutstandingBalance(date(2023, time.March, 20), idx)
+	require.Nil(t, err)
+	require.True(t, dec("275225").Equal(balance), balance.String())
+}
+
+func TestPaymentPlan_Payoff(t *testing.T) {
+	sale := newSale(t, "300000")
+	plan, err := entity.NewPaymentPlan("", &sale, date(2023, time.January, 10), date(2023, time.June, 30), planTerms,
+		time.Time{})
+	require.Nil(t, err)
+	idx := newIndexes(t)
+	require.Nil(t, plan.PayInstallment(plan.Installments[0].ID, dec("30000"), date(2023, time.January, 15), idx))
+
+	quote, err := plan.QuotePayoff(date(2023, time.March, 20), dec("1"), idx)
+	require.Nil(t, err)
+	require.Len(t, quote.Installments, 7)
+	require.True(t, dec("275225").Equal(quote.Balance), quote.Balance.String())
+	require.True(t, dec("269520.83").Equal(quote.Amount), quote.Amount.String())
+	require.True(t, dec("5704.17").Equal(quote.Discount), quote.Discount.String())
+
+	outdated := quote
+	outdated.Installments = outdated.Installments[1:]
+	require.Equal(t, entity.ErrPayoffQuoteOutdated, plan.Settle(outdated, date(2023, time.March, 20)))
+	require.Equal(t, entity.ErrPayoffQuoteExpired, plan.Settle(quote, date(2023, time.April, 20)))
+	require.Nil(t, plan.Payoff)
+
+	require.Nil(t, plan.Settle(quote, date(2023, time.March, 20)))
+	require.NotNil(t, plan.Payoff)
+
+	paid := dec("0")
+	for _, i := range plan.Installments[1:] {
+		require.Equal(t, entity.InstallmentDischarged, i.Status)
+		paid = paid.Add(i.PaidAmount)
+	}
+	require.True(t, quote.Amount.Equal(paid))
+
+	_, err = plan.QuotePayoff(date(2023, time.March, 21), dec("1"), idx)
+	require.Equal(t, entity.ErrPlanSettled, err)
+}
+
+func TestPaymentPlan_PayoffOfFewCents(t *testing.T) {
+	sale := newSale(t, "300000")
+	plan, err := entity.NewPaymentPlan("", &sale, date(2023, time.January, 10), date(2023, time.June, 30), planTerms,
+		time.Time{})
+	require.Nil(t, err)
+	idx := newIndexes(t)
+
+	quote, err := plan.QuotePayoff(date(2023, time.January, 10), dec("1"), idx)
+	require.Nil(t, err)
+	quote.Amount = dec("0.05")
+
+	require.Nil(t, plan.Settle(quote, date(2023, time.January, 10)))
+
+	paid, unpaid := dec("0"), 0
+	for _, i := range plan.Installments {
+		require.Equal(t, entity.InstallmentDischarged, i.Status)
+		paid = paid.Add(i.PaidAmount)
+		if i.PaidAmount.IsZero() {
+			unpaid++
+		}
+	}
+	require.True(t, dec("0.05").Equal(paid))
+	require.Equal(t, 5, unpaid)
+}
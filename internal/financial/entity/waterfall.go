@@ -0,0 +1,200 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	construction "github.com/LHS-Real-Estate/cim-core/internal/construction/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/shopspring/decimal"
+)
+
+type TierKind string
+
+const (
+	ReturnOfCapital TierKind = "return_of_capital"
+	PreferredReturn TierKind = "preferred_return"
+	ProRata         TierKind = "pro_rata"
+)
+
+var ErrWaterfallWithoutProRata = errors.New("waterfall must end with a pro rata tier")
+
+// Tier is a step of the waterfall. AnnualRate is only used by the preferred return, accrued
+// simple and pro rata die (365 days a year) on the capital not yet returned.
+type Tier struct {
+	Kind       TierKind        `validate:"required,oneof=return_of_capital preferred_return pro_rata"`
+	AnnualRate decimal.Decimal `validate:"required_if=Kind preferred_return,gte=0"`
+}
+
+// Waterfall is the order in which a distribution pays the investors. Each tier is paid in full
+// before the next one, and the last one, split by stake, takes whatever is left.
+type Waterfall struct {
+	Tiers []Tier `validate:"required,min=1,dive"`
+}
+
+// DefaultWaterfall returns capital first, then a preferred return at the annual rate, and
+// splits the rest by stake.
+func DefaultWaterfall(preferredAnnualRate decimal.Decimal) Waterfall {
+	return Waterfall{Tiers: []Tier{
+		{Kind: ReturnOfCapital},
+		{Kind: PreferredReturn, AnnualRate: preferredAnnualRate},
+		{Kind: ProRata},
+	}}
+}
+
+func (w Waterfall) Validate() error {
+	cv := validator.NewCustomValidate()
+	if err := cv.Validate(w); err != nil {
+		return err
+	}
+
+	if w.Tiers[len(w.Tiers)-1].Kind != ProRata {
+		return ErrWaterfallWithoutProRata
+	}
+	return nil
+}
+
+// investorPosition is what an investor is owed by each tier before the distribution.
+type investorPosition struct {
+	investment  construction.Investment
+	contributed decimal.Decimal
+	unreturned  decimal.Decimal
+	prefOwed    decimal.Decimal
+}
+
+type capitalMovement struct {
+	at     time.Time
+	amount decimal.Decimal
+}
+
+// position replays the paid contributions, the principal paid on capital calls and the capital
+// returned by previous distributions to find the capital still invested and the preferred
+// return not paid yet.
+func position(inv construction.Investment, at time.Time, annualRate decimal.Decimal, calls []CapitalCall,
+	previous []Distribution) investorPosition {
+
+	p := investorPosition{investment: inv, contributed: decimal.Zero, prefOwed: decimal.Zero}
+
+	var movements []capitalMovement
+	for _, c := range inv.Contributions {
+		if c.Status == construction.ContributionPaid && !day(c.PaidAt).After(at) {
+			movements = append(movements, capitalMovement{at: day(c.PaidAt), amount: c.Amount})
+			p.contributed = p.contributed.Add(c.Amount)
+		}
+	}
+
+	for _, m := range callMovements(inv, at, calls) {
+		movements = append(movements, m)
+		p.contributed = p.contributed.Add(m.amount)
+	}
+
+	prefPaid := decimal.Zero
+	for _, d := range previous {
+		for _, l := range d.Lines {
+			if l.PartnerID != inv.PartnerID {
+				continue
+			}
+			switch l.Tier {
+			case ReturnOfCapital:
+				movements = append(movements, capitalMovement{at: d.Date, amount: l.Amount.Neg()})
+			case PreferredReturn:
+				prefPaid = prefPaid.Add(l.Amount)
+			}
+		}
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool { return movements[i].at.Before(movements[j].at) })
+
+	capital, accrued := decimal.Zero, decimal.Zero
+	for i, m := range movements {
+		capital = capital.Add(m.amount)
+
+		until := at
+		if i+1 < len(movements) {
+			until = movements[i+1].at
+		}
+
+		days := int64(until.Sub(m.at).Hours() / 24)
+		if days > 0 && capital.IsPositive() {
+			accrued = accrued.Add(capital.Mul(annualRate).Div(decimal.NewFromInt(100)).
+				Mul(decimal.NewFromInt(days)).Div(decimal.NewFromInt(365)))
+		}
+	}
+
+	p.unreturned = capital
+	if !p.unreturned.IsPositive() {
+		p.unreturned = decimal.Zero
+	}
+
+	p.prefOwed = accrued.Round(moneyPlaces).Sub(prefPaid)
+	if !p.prefOwed.IsPositive() {
+		p.prefOwed = decimal.Zero
+	}
+
+	return p
+}
+
+// callMovements are the principal paid by the investor on the capital calls of the
+// construction until the date, one movement per payment date. Late charges are not capital.
+func callMovements(inv construction.Investment, at time.Time, calls []CapitalCall) []capitalMovement {
+	var movements []capitalMovement
+	for _, c := range calls {
+		if c.ConstructionID != inv.ConstructionID {
+			continue
+		}
+
+		a, ok := c.Allocation(inv.PartnerID)
+		if !ok || a.InvestmentID != inv.ID {
+			continue
+		}
+
+		var dates []time.Time
+		for _, pay := range a.Payments {
+			if d := day(pay.PaidAt); !d.After(at) {
+				dates = append(dates, d)
+			}
+		}
+		sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+		paid := decimal.Zero
+		for i, d := range dates {
+			if i > 0 && d.Equal(dates[i-1]) {
+				continue
+			}
+
+			s, err := c.Statement(inv.PartnerID, d)
+			if err != nil {
+				continue
+			}
+			if s.PrincipalPaid.GreaterThan(paid) {
+				movements = append(movements, capitalMovement{at: d, amount: s.PrincipalPaid.Sub(paid)})
+				paid = s.PrincipalPaid
+			}
+		}
+	}
+	return movements
+}
+
+// payTier pays what each investor is owed by a tier or, if the amount is not enough, splits it
+// pro rata to what each one is owed.
+func payTier(available decimal.Decimal, positions []investorPosition,
+	owed func(p investorPosition) decimal.Decimal) ([]decimal.Decimal, error) {
+
+	total := decimal.Zero
+	shares := make([]Share, len(positions))
+	for i, p := range positions {
+		shares[i] = Share{Key: p.investment.PartnerID, Weight: owed(p)}
+		total = total.Add(owed(p))
+	}
+
+	if available.GreaterThanOrEqual(total) {
+		parts := make([]decimal.Decimal, len(positions))
+		for i, p := range positions {
+			parts[i] = owed(p)
+		}
+		return parts, nil
+	}
+
+	return AllocateProRata(available, shares)
+}
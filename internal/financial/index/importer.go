@@ -0,0 +1,268 @@
+package index
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrUnknownFormat = errors.New("index file format not recognized")
+	ErrInvalidValue  = errors.New("invalid index value")
+)
+
+// errNoValues is returned by every reader for a publication without any value.
+var errNoValues = fmt.Errorf("%w: no dated values", ErrUnknownFormat)
+
+// Column headers, without accents and upper cased, of the BCB SGS and FGV IBRE csv exports.
+var (
+	dateHeaders  = []string{"DATA", "DATE", "MES", "PERIODO", "REFERENCIA"}
+	valueHeaders = []string{"VALOR", "VALUE", "VARIACAO", "TAXA"}
+)
+
+// ReadCSV reads the values of a csv file, separated by semicolons, as published in Brazil, or by
+// commas. The separator is taken from the header line, as titles and notes may hold either.
+// Rows above the header and rows without a date, such as the source notes FGV appends, are
+// skipped. Without a header the first column is the date and the second the value.
+func ReadCSV(r io.Reader) ([]Value, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading index csv: %w", err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = detectComma(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading index csv: %w", err)
+	}
+
+	dateCol, valueCol, start := 0, 1, 0
+	for i, row := range rows {
+		if d, v, ok := headerColumns(row); ok {
+			dateCol, valueCol, start = d, v, i+1
+			break
+		}
+	}
+
+	var values []Value
+	for _, row := range rows[start:] {
+		if dateCol >= len(row) || valueCol >= len(row) {
+			continue
+		}
+
+		date, err := parseDate(row[dateCol])
+		if err != nil {
+			continue
+		}
+
+		v, ok, err := parseValue(row[valueCol])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %q", err, row[dateCol], row[valueCol])
+		}
+		if ok {
+			values = append(values, Value{Date: date, Percent: v})
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, errNoValues
+	}
+	return values, nil
+}
+
+// detectComma returns the separator of the header line or, in files without a header, of the
+// first dated row. It defaults to the comma.
+func detectComma(content []byte) rune {
+	lines := strings.Split(string(content), "\n")
+	commas := []rune{';', ','}
+
+	for _, line := range lines {
+		for _, comma := range commas {
+			if _, _, ok := headerColumns(splitLine(line, comma)); ok {
+				return comma
+			}
+		}
+	}
+
+	for _, line := range lines {
+		for _, comma := range commas {
+			if row := splitLine(line, comma); len(row) > 1 {
+				if _, err := parseDate(row[0]); err == nil {
+					return comma
+				}
+			}
+		}
+	}
+	return ','
+}
+
+func splitLine(line string, comma rune) []string {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = comma
+	reader.LazyQuotes = true
+
+	row, err := reader.Read()
+	if err != nil {
+		return nil
+	}
+	return row
+}
+
+func headerColumns(row []string) (int, int, bool) {
+	headers := make([]string, len(row))
+	for i, h := range row {
+		headers[i] = normalize(h)
+	}
+
+	d, v := findHeader(headers, dateHeaders), findHeader(headers, valueHeaders)
+	return d, v, d >= 0 && v >= 0 && d != v
+}
+
+// ReadJSON reads the values of the BCB SGS API, [{"data":"01/01/2023","valor":"0.53"}], or of
+// the IBGE SIDRA API, whose first element names the columns and whose month code column is
+// named "Mês (Código)".
+func ReadJSON(r io.Reader) ([]Value, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var rows []map[string]any
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+
+	if len(rows) == 0 {
+		return nil, errNoValues
+	}
+
+	if _, ok := rows[0]["data"]; ok {
+		return readRows(rows, "data", "valor")
+	}
+
+	for key, name := range rows[0] {
+		if strings.HasPrefix(normalize(fmt.Sprint(name)), "MES (CODIGO)") {
+			return readRows(rows[1:], key, "V")
+		}
+	}
+
+	return nil, fmt.Errorf("%w: json without BCB or SIDRA columns", ErrUnknownFormat)
+}
+
+func readRows(rows []map[string]any, dateKey string, valueKey string) ([]Value, error) {
+	values := make([]Value, 0, len(rows))
+	for _, row := range rows {
+		rawDate, rawValue := fmt.Sprint(row[dateKey]), fmt.Sprint(row[valueKey])
+
+		date, err := parseDate(rawDate)
+		if err != nil {
+			return nil, err
+		}
+
+		v, ok, err := parseValue(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %q", err, rawDate, rawValue)
+		}
+		if ok {
+			values = append(values, Value{Date: date, Percent: v})
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, errNoValues
+	}
+	return values, nil
+}
+
+var monthNames = map[string]time.Month{
+	"JAN": time.January, "FEV": time.February, "MAR": time.March, "ABR": time.April,
+	"MAI": time.May, "JUN": time.June, "JUL": time.July, "AGO": time.August,
+	"SET": time.September, "OUT": time.October, "NOV": time.November, "DEZ": time.December,
+}
+
+var (
+	dateLayouts    = []string{"02/01/2006", "01/2006", time.DateOnly, "2006-01", "200601"}
+	monthNameRegex = regexp.MustCompile(`^([A-Z]{3})[A-Z]*[/\-. ]+(\d{2}|\d{4})$`)
+)
+
+// parseDate accepts the numeric dates of BCB and SIDRA as well as the month names FGV uses,
+// such as "jan/23" or "janeiro 2023".
+func parseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	if m := monthNameRegex.FindStringSubmatch(normalize(s)); m != nil {
+		if month, ok := monthNames[m[1]]; ok {
+			year, err := time.Parse("2006", m[2])
+			if len(m[2]) == 2 {
+				year, err = time.Parse("06", m[2])
+			}
+			if err == nil {
+				return time.Date(year.Year(), month, 1, 0, 0, 0, 0, time.UTC), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: date %q", ErrUnknownFormat, s)
+}
+
+// parseValue accepts both the Brazilian notation of the csv files and the dot of the APIs. It is
+// not ok for the marks of unavailable values.
+func parseValue(s string) (decimal.Decimal, bool, error) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	switch s {
+	case "", "-", "...", "..", "X", "<nil>":
+		return decimal.Zero, false, nil
+	}
+
+	if strings.Contains(s, ",") {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+
+	v, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, false, ErrInvalidValue
+	}
+	return v, true, nil
+}
+
+// findHeader returns the first column starting with the candidates, in the order of preference
+// of the candidates.
+func findHeader(headers []string, candidates []string) int {
+	for _, candidate := range candidates {
+		for i, h := range headers {
+			if strings.HasPrefix(h, candidate) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func normalize(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, s)
+	if err != nil {
+		stripped = s
+	}
+	return strings.Join(strings.Fields(strings.ToUpper(stripped)), " ")
+}
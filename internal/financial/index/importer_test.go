@@ -0,0 +1,143 @@
+package index_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	type testCase struct {
+		test        string
+		content     string
+		expected    []index.Value
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:    "Should read the BCB SGS csv",
+			content: "\"data\";\"valor\"\n\"02/01/2023\";\"0,050788\"\n\"03/01/2023\";\"0,050788\"\n",
+			expected: []index.Value{
+				{Date: time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC), Percent: decimal.RequireFromString("0.050788")},
+				{Date: time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC), Percent: decimal.RequireFromString("0.050788")},
+			},
+		},
+		{
+			test:    "Should read the FGV csv skipping titles, notes and unavailable values",
+			content: "INCC-M - variação mensal\nMês;Variação (%)\njan/23;0,32\nfevereiro 2023;0,18\nmar/2023;-\nFonte: FGV IBRE\n",
+			expected: []index.Value{
+				{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.32")},
+				{Date: month(2023, time.February), Percent: decimal.RequireFromString("0.18")},
+			},
+		},
+		{
+			test:    "Should take the separator from the header line",
+			content: "Série 433; IPCA\nData,Valor\n2023-01,\"0,53\"\nFonte: BCB; IBGE\n",
+			expected: []index.Value{
+				{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.53")},
+			},
+		},
+		{
+			test:    "Should read headerless comma separated files",
+			content: "2023-01,0.53\n2023-02,0.84\n",
+			expected: []index.Value{
+				{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.53")},
+				{Date: month(2023, time.February), Percent: decimal.RequireFromString("0.84")},
+			},
+		},
+		{
+			test:        "Should fail on invalid values",
+			content:     "data;valor\n01/01/2023;abc\n",
+			expectedErr: index.ErrInvalidValue,
+		},
+		{
+			test:        "Should fail without dated rows",
+			content:     "nothing;here\n",
+			expectedErr: index.ErrUnknownFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		values, err := index.ReadCSV(strings.NewReader(tc.content))
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr), err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, values)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	type testCase struct {
+		test        string
+		content     string
+		expected    []index.Value
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:    "Should read the BCB SGS json",
+			content: `[{"data":"01/01/2023","valor":"0.53"},{"data":"01/02/2023","valor":"0.84"}]`,
+			expected: []index.Value{
+				{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.53")},
+				{Date: month(2023, time.February), Percent: decimal.RequireFromString("0.84")},
+			},
+		},
+		{
+			test: "Should read the IBGE SIDRA json",
+			content: `[{"NC":"Nível Territorial (Código)","V":"Valor","D2C":"Mês (Código)","D2N":"Mês"},` +
+				`{"NC":"1","V":"0.53","D2C":"202301","D2N":"janeiro 2023"},` +
+				`{"NC":"1","V":"...","D2C":"202302","D2N":"fevereiro 2023"}]`,
+			expected: []index.Value{
+				{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.53")},
+			},
+		},
+		{
+			test:        "Should fail on unknown columns",
+			content:     `[{"date":"2023-01-01","value":1}]`,
+			expectedErr: index.ErrUnknownFormat,
+		},
+		{
+			test:        "Should fail on an empty publication",
+			content:     `[]`,
+			expectedErr: index.ErrUnknownFormat,
+		},
+		{
+			test:        "Should fail on a publication without values",
+			content:     `[{"data":"01/01/2023","valor":""}]`,
+			expectedErr: index.ErrUnknownFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		values, err := index.ReadJSON(strings.NewReader(tc.content))
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr), err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, values)
+	}
+}
+
+func TestReadEmptyPublication(t *testing.T) {
+	_, csvErr := index.ReadCSV(strings.NewReader("Data;Valor\n"))
+	_, jsonErr := index.ReadJSON(strings.NewReader("[]"))
+	require.True(t, errors.Is(csvErr, index.ErrUnknownFormat))
+	require.Equal(t, csvErr, jsonErr)
+}
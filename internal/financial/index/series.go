@@ -0,0 +1,401 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/calendar"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/shopspring/decimal"
+)
+
+type Name string
+
+const (
+	INCC  Name = "INCC-M"
+	IGPM  Name = "IGP-M"
+	IPCA  Name = "IPCA"
+	CDI   Name = "CDI"
+	SELIC Name = "SELIC"
+)
+
+type Periodicity string
+
+const (
+	Monthly Periodicity = "monthly"
+	Daily   Periodicity = "daily"
+)
+
+// Periodicity tells how an index is published: price indexes as monthly variations, interest
+// rates as daily rates of each banking day.
+func (n Name) Periodicity() Periodicity {
+	switch n {
+	case CDI, SELIC:
+		return Daily
+	default:
+		return Monthly
+	}
+}
+
+// SourceManual is the source of the values typed by users, which do not come from a published file.
+const SourceManual = "manual"
+
+var (
+	ErrMissingValue   = errors.New("index value not available")
+	ErrNotBusinessDay = errors.New("daily index has no value on non-business days")
+	ErrMissingSource  = errors.New("import requires the name of its source")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Value is the variation of an index for a period, in percent. Monthly values are dated on the
+// first day of their month.
+type Value struct {
+	Date    time.Time
+	Percent decimal.Decimal
+}
+
+// Change is an audit record of a value stored in a series. Previous is not valid when the
+// period had no value before.
+type Change struct {
+	Date     time.Time
+	Previous decimal.NullDecimal
+	Percent  decimal.Decimal
+	Source   string
+	Author   string
+	Reason   string
+	At       time.Time
+}
+
+type ManualEntry struct {
+	Date    time.Time       `validate:"required"`
+	Percent decimal.Decimal `validate:"-"`
+	Author  string          `validate:"required,min=3,max=255"`
+	Reason  string          `validate:"required,min=3,max=1000"`
+	At      time.Time       `validate:"-"`
+}
+
+type ImportResult struct {
+	Added     int
+	Updated   int
+	Unchanged int
+}
+
+// Series keeps the variations of an economic index, in percent, with the audit trail of every
+// change. It is safe for concurrent use.
+type Series struct {
+	name     Name
+	calendar *calendar.Calendar
+	mu       sync.RWMutex
+	values   map[string]decimal.Decimal
+	trail    []Change
+}
+
+func NewSeries(name Name) *Series {
+	return &Series{name: name, calendar: calendar.NewCalendar(), values: make(map[string]decimal.Decimal)}
+}
+
+func (s *Series) Name() Name {
+	return s.name
+}
+
+func (s *Series) Periodicity() Periodicity {
+	return s.name.Periodicity()
+}
+
+// Enter stores a value typed by a user, who must tell why.
+func (s *Series) Enter(entry ManualEntry) error {
+	if entry.At.IsZero() {
+		entry.At = time.Now()
+	}
+
+	if err := validator.NewCustomValidate().Validate(entry); err != nil {
+		return err
+	}
+
+	if err := s.checkDate(entry.Date); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(Value{Date: entry.Date, Percent: entry.Percent},
+		Change{Source: SourceManual, Author: entry.Author, Reason: entry.Reason, At: entry.At})
+	return nil
+}
+
+// Import stores the values read from a published file. Values equal to the stored ones are not
+// recorded again. Nothing is stored when one of the values is invalid.
+func (s *Series) Import(values []Value, source string, at time.Time) (ImportResult, error) {
+	if source == "" {
+		return ImportResult{}, ErrMissingSource
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	for _, v := range values {
+		if err := s.checkDate(v.Date); err != nil {
+			return ImportResult{}, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result ImportResult
+	for _, v := range values {
+		previous, ok := s.values[s.key(v.Date)]
+		switch {
+		case !ok:
+			result.Added++
+		case previous.Equal(v.Percent):
+			result.Unchanged++
+			continue
+		default:
+			result.Updated++
+		}
+		s.store(v, Change{Source: source, At: at})
+	}
+	return result, nil
+}
+
+// store must be called with the lock held.
+func (s *Series) store(v Value, change Change) {
+	key := s.key(v.Date)
+	previous, ok := s.values[key]
+
+	change.Date = s.period(v.Date)
+	change.Previous = decimal.NullDecimal{Decimal: previous, Valid: ok}
+	change.Percent = v.Percent
+
+	s.values[key] = v.Percent
+	s.trail = append(s.trail, change)
+}
+
+func (s *Series) checkDate(date time.Time) error {
+	if s.Periodicity() == Daily && !s.calendar.IsWorkday(date) {
+		return fmt.Errorf("%w: %s %s", ErrNotBusinessDay, s.name, date.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// AuditTrail lists every change of the series, oldest first.
+func (s *Series) AuditTrail() []Change {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Change(nil), s.trail...)
+}
+
+// History lists the changes of the period of date, oldest first.
+func (s *Series) History(date time.Time) []Change {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var changes []Change
+	for _, c := range s.trail {
+		if s.key(c.Date) == s.key(date) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Variation returns the value of the period of date: its month for monthly indexes, its day for
+// daily ones.
+func (s *Series) Variation(date time.Time) (decimal.Decimal, error) {
+	if err := s.checkDate(date); err != nil {
+		return decimal.Zero, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.values[s.key(date)]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: %s %s", ErrMissingValue, s.name, s.key(date))
+	}
+	return v, nil
+}
+
+// Latest returns the last value published for a period up to date, as indexes are published
+// after the period they measure.
+func (s *Series) Latest(date time.Time) (Value, error) {
+	values := s.Values()
+	for i := len(values) - 1; i >= 0; i-- {
+		if !values[i].Date.After(date) {
+			return values[i], nil
+		}
+	}
+	return Value{}, fmt.Errorf("%w: %s up to %s", ErrMissingValue, s.name, date.Format(time.DateOnly))
+}
+
+// Values lists the stored values, oldest first.
+func (s *Series) Values() []Value {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make([]Value, 0, len(s.values))
+	for k, v := range s.values {
+		values = append(values, Value{Date: s.parseKey(k), Percent: v})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Date.Before(values[j].Date) })
+	return values
+}
+
+// Months lists the months with a value, oldest first.
+func (s *Series) Months() []time.Time {
+	var months []time.Time
+	for _, v := range s.Values() {
+		m := firstOfMonth(v.Date)
+		if len(months) == 0 || !months[len(months)-1].Equal(m) {
+			months = append(months, m)
+		}
+	}
+	return months
+}
+
+// Factor compounds the variations from from, inclusive, to to, exclusive. Monthly indexes
+// count whole months, so it is 1 when both dates are in the same month. Daily indexes count
+// the business days.
+func (s *Series) Factor(from time.Time, to time.Time) (decimal.Decimal, error) {
+	if s.Periodicity() == Daily {
+		return s.dailyFactor(from, to)
+	}
+
+	factor := decimal.NewFromInt(1)
+	for m := firstOfMonth(from); m.Before(firstOfMonth(to)); m = m.AddDate(0, 1, 0) {
+		v, err := s.Variation(m)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		factor = factor.Mul(hundred.Add(v).Div(hundred))
+	}
+	return factor, nil
+}
+
+// ProRataFactor compounds the variations between two dates pro rata die: each month of a
+// monthly index contributes its factor raised to the fraction of its calendar days in the
+// period. Daily indexes already compound per business day.
+func (s *Series) ProRataFactor(from time.Time, to time.Time) (decimal.Decimal, error) {
+	if s.Periodicity() == Daily {
+		return s.dailyFactor(from, to)
+	}
+
+	from, to = day(from), day(to)
+	factor := decimal.NewFromInt(1)
+	for m := firstOfMonth(from); m.Before(to); m = m.AddDate(0, 1, 0) {
+		next := m.AddDate(0, 1, 0)
+		days := daysBetween(maxTime(m, from), minTime(next, to))
+		if days <= 0 {
+			continue
+		}
+
+		v, err := s.Variation(m)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		monthFactor := hundred.Add(v).Div(hundred)
+		if monthDays := daysBetween(m, next); days < monthDays {
+			exponent := decimal.NewFromInt(int64(days)).Div(decimal.NewFromInt(int64(monthDays)))
+			if monthFactor, err = monthFactor.PowWithPrecision(exponent, 16); err != nil {
+				return decimal.Zero, err
+			}
+		}
+		factor = factor.Mul(monthFactor)
+	}
+	return factor, nil
+}
+
+func (s *Series) dailyFactor(from time.Time, to time.Time) (decimal.Decimal, error) {
+	factor := decimal.NewFromInt(1)
+	for d := day(from); d.Before(day(to)); d = d.AddDate(0, 0, 1) {
+		if !s.calendar.IsWorkday(d) {
+			continue
+		}
+
+		v, err := s.Variation(d)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		factor = factor.Mul(hundred.Add(v).Div(hundred))
+	}
+	return factor, nil
+}
+
+// Correct updates an amount from one month to another, rounded to cents.
+func (s *Series) Correct(amount decimal.Decimal, from time.Time, to time.Time) (decimal.Decimal, error) {
+	factor, err := s.Factor(from, to)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(factor).Round(2), nil
+}
+
+// CorrectProRata updates an amount between two dates with the pro rata die factor, rounded to
+// cents.
+func (s *Series) CorrectProRata(amount decimal.Decimal, from time.Time, to time.Time) (decimal.Decimal, error) {
+	factor, err := s.ProRataFactor(from, to)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(factor).Round(2), nil
+}
+
+func (s *Series) period(date time.Time) time.Time {
+	if s.Periodicity() == Daily {
+		return day(date)
+	}
+	return firstOfMonth(date)
+}
+
+func (s *Series) key(date time.Time) string {
+	if s.Periodicity() == Daily {
+		return day(date).Format(time.DateOnly)
+	}
+	return monthKey(date)
+}
+
+func (s *Series) parseKey(key string) time.Time {
+	layout := "2006-01"
+	if s.Periodicity() == Daily {
+		layout = time.DateOnly
+	}
+	t, _ := time.Parse(layout, key)
+	return t
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func minTime(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func monthKey(t time.Time) string {
+	return t.Format("2006-01")
+}
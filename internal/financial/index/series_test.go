@@ -0,0 +1,221 @@
+package index_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/financial/index"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func month(y int, m time.Month) time.Time {
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSeries_Correct(t *testing.T) {
+	incc := index.NewSeries(index.INCC)
+	_, err := incc.Import([]index.Value{
+		{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.32")},
+		{Date: month(2023, time.February), Percent: decimal.RequireFromString("0.18")},
+		{Date: month(2023, time.March), Percent: decimal.RequireFromString("-0.10")},
+	}, "FGV IBRE", time.Time{})
+	require.Nil(t, err)
+
+	type testCase struct {
+		test        string
+		from        time.Time
+		to          time.Time
+		expected    string
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:     "Should not correct within the same month",
+			from:     month(2023, time.January),
+			to:       time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			expected: "100000",
+		},
+		{
+			test:     "Should compound the monthly variations",
+			from:     month(2023, time.January),
+			to:       time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC),
+			expected: "100400.08",
+		},
+		{
+			test:        "Should fail when a month is missing",
+			from:        month(2023, time.February),
+			to:          month(2023, time.May),
+			expectedErr: index.ErrMissingValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		corrected, err := incc.Correct(decimal.NewFromInt(100000), tc.from, tc.to)
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr))
+			continue
+		}
+
+		require.Nil(t, err)
+		require.True(t, decimal.RequireFromString(tc.expected).Equal(corrected), corrected.String())
+	}
+
+	require.Equal(t, []time.Time{month(2023, time.January), month(2023, time.February), month(2023, time.March)},
+		incc.Months())
+}
+
+func TestSeries_CorrectProRata(t *testing.T) {
+	incc := index.NewSeries(index.INCC)
+	_, err := incc.Import([]index.Value{
+		{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.32")},
+		{Date: month(2023, time.February), Percent: decimal.RequireFromString("0.18")},
+		{Date: month(2023, time.March), Percent: decimal.RequireFromString("-0.10")},
+	}, "FGV IBRE", time.Time{})
+	require.Nil(t, err)
+
+	cdi := index.NewSeries(index.CDI)
+	var rates []index.Value
+	for _, d := range []int{3, 4, 5, 6, 10} {
+		rates = append(rates, index.Value{
+			Date: time.Date(2023, time.April, d, 0, 0, 0, 0, time.UTC), Percent: decimal.RequireFromString("0.050788"),
+		})
+	}
+	_, err = cdi.Import(rates, "BCB SGS", time.Time{})
+	require.Nil(t, err)
+
+	type testCase struct {
+		test        string
+		series      *index.Series
+		from        time.Time
+		to          time.Time
+		expected    string
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:     "Should match whole months when both dates are on the first day",
+			series:   incc,
+			from:     month(2023, time.January),
+			to:       month(2023, time.April),
+			expected: "100400.08",
+		},
+		{
+			test:     "Should raise the factor to the fraction of days within a month",
+			series:   incc,
+			from:     month(2023, time.January),
+			to:       time.Date(2023, time.January, 16, 0, 0, 0, 0, time.UTC),
+			expected: "100154.71",
+		},
+		{
+			test:     "Should prorate the first and last months",
+			series:   incc,
+			from:     time.Date(2023, time.January, 16, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC),
+			expected: "100300",
+		},
+		{
+			test:        "Should fail when a prorated month is missing",
+			series:      incc,
+			from:        time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC),
+			to:          time.Date(2023, time.April, 10, 0, 0, 0, 0, time.UTC),
+			expectedErr: index.ErrMissingValue,
+		},
+		{
+			test:     "Should compound daily rates over business days only",
+			series:   cdi,
+			from:     time.Date(2023, time.April, 3, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2023, time.April, 11, 0, 0, 0, 0, time.UTC),
+			expected: "100254.20",
+		},
+		{
+			test:        "Should fail when a business day is missing",
+			series:      cdi,
+			from:        time.Date(2023, time.April, 10, 0, 0, 0, 0, time.UTC),
+			to:          time.Date(2023, time.April, 12, 0, 0, 0, 0, time.UTC),
+			expectedErr: index.ErrMissingValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		corrected, err := tc.series.CorrectProRata(decimal.NewFromInt(100000), tc.from, tc.to)
+		if tc.expectedErr != nil {
+			require.True(t, errors.Is(err, tc.expectedErr))
+			continue
+		}
+
+		require.Nil(t, err)
+		require.True(t, decimal.RequireFromString(tc.expected).Equal(corrected), corrected.String())
+	}
+
+	_, err = cdi.Variation(time.Date(2023, time.April, 7, 0, 0, 0, 0, time.UTC))
+	require.True(t, errors.Is(err, index.ErrNotBusinessDay))
+}
+
+func TestSeries_Audit(t *testing.T) {
+	ipca := index.NewSeries(index.IPCA)
+	importedAt := time.Date(2023, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	result, err := ipca.Import([]index.Value{
+		{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.53")},
+		{Date: month(2023, time.February), Percent: decimal.RequireFromString("0.84")},
+	}, "ipca.json", importedAt)
+	require.Nil(t, err)
+	require.Equal(t, index.ImportResult{Added: 2}, result)
+
+	result, err = ipca.Import([]index.Value{
+		{Date: month(2023, time.January), Percent: decimal.RequireFromString("0.53")},
+	}, "ipca.json", importedAt)
+	require.Nil(t, err)
+	require.Equal(t, index.ImportResult{Unchanged: 1}, result)
+
+	_, err = ipca.Import(nil, "", importedAt)
+	require.Equal(t, index.ErrMissingSource, err)
+
+	err = ipca.Enter(index.ManualEntry{Date: month(2023, time.February), Percent: decimal.RequireFromString("0.80")})
+	require.Equal(t, errors.New("invalid fields: ManualEntry.Author: \"\", ManualEntry.Reason: \"\""), err)
+
+	enteredAt := importedAt.Add(time.Hour)
+	err = ipca.Enter(index.ManualEntry{
+		Date:    time.Date(2023, time.February, 20, 0, 0, 0, 0, time.UTC),
+		Percent: decimal.RequireFromString("0.80"),
+		Author:  "Maria Souza",
+		Reason:  "Typo in the published file",
+		At:      enteredAt,
+	})
+	require.Nil(t, err)
+
+	history := ipca.History(month(2023, time.February))
+	require.Len(t, history, 2)
+	require.Equal(t, "ipca.json", history[0].Source)
+	require.False(t, history[0].Previous.Valid)
+	require.Equal(t, index.SourceManual, history[1].Source)
+	require.Equal(t, "Maria Souza", history[1].Author)
+	require.Equal(t, enteredAt, history[1].At)
+	require.True(t, decimal.RequireFromString("0.84").Equal(history[1].Previous.Decimal))
+	require.Len(t, ipca.AuditTrail(), 3)
+
+	latest, err := ipca.Latest(time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC))
+	require.Nil(t, err)
+	require.Equal(t, month(2023, time.February), latest.Date)
+	require.True(t, decimal.RequireFromString("0.80").Equal(latest.Percent))
+
+	_, err = ipca.Latest(time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC))
+	require.True(t, errors.Is(err, index.ErrMissingValue))
+
+	selic := index.NewSeries(index.SELIC)
+	_, err = selic.Import([]index.Value{
+		{Date: time.Date(2023, time.April, 6, 0, 0, 0, 0, time.UTC), Percent: decimal.RequireFromString("0.050788")},
+		{Date: time.Date(2023, time.April, 8, 0, 0, 0, 0, time.UTC), Percent: decimal.RequireFromString("0.050788")},
+	}, "selic.csv", importedAt)
+	require.True(t, errors.Is(err, index.ErrNotBusinessDay))
+	require.Empty(t, selic.Values())
+}
@@ -0,0 +1,37 @@
+package entity
+
+type Channel string
+
+const (
+	ChannelEmail    Channel = "email"
+	ChannelWhatsApp Channel = "whatsapp"
+	ChannelSMS      Channel = "sms"
+)
+
+type Destination struct {
+	Channel Channel
+	Address string
+}
+
+// Recipient is someone the notification subsystem may contact. Destinations only hold the
+// channels the recipient opted in to, in order of preference.
+type Recipient struct {
+	ID           string
+	Name         string
+	Language     string
+	Destinations []Destination
+}
+
+func (r Recipient) AddressesFor(channel Channel) []string {
+	var addresses []string
+	for _, d := range r.Destinations {
+		if d.Channel == channel {
+			addresses = append(addresses, d.Address)
+		}
+	}
+	return addresses
+}
+
+func (r Recipient) Reachable() bool {
+	return len(r.Destinations) > 0
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+type WithdrawalReason string
+
+const (
+	WithdrawalVoluntary     WithdrawalReason = "voluntary"
+	WithdrawalExclusion     WithdrawalReason = "exclusion"
+	WithdrawalDeath         WithdrawalReason = "death"
+	WithdrawalQuotaTransfer WithdrawalReason = "quota_transfer"
+	WithdrawalDissolution   WithdrawalReason = "dissolution"
+	// WithdrawalUnknown is used for withdrawals migrated from legacy records, which did not
+	// register the reason.
+	WithdrawalUnknown WithdrawalReason = "unknown"
+)
+
+var (
+	ErrAlreadyActive  = errors.New("partner is already active in the company")
+	ErrNotActive      = errors.New("partner is not active in the company")
+	ErrBeforeLastExit = errors.New("partner cannot rejoin the company before the last withdrawal")
+	ErrExitBeforeJoin = errors.New("partner cannot withdraw before joining the company")
+)
+
+// ActivityPeriod is a period during which the partner was a member of the company, from
+// JoinedAt (inclusive) until WithdrawnAt (exclusive). A zero WithdrawnAt means the period is open.
+// A period withdrawn when it was joined is empty; it only comes from legacy records without a
+// withdrawal date.
+type ActivityPeriod struct {
+	JoinedAt             time.Time        `validate:"required"`
+	WithdrawnAt          time.Time        `validate:"omitempty,gtefield=JoinedAt"`
+	WithdrawalReason     WithdrawalReason `validate:"required_with=WithdrawnAt,omitempty,oneof=voluntary exclusion death quota_transfer dissolution unknown"`
+	WithdrawalNotes      string           `validate:""`
+	WithdrawalDocumentID string           `validate:"omitempty,uuid"`
+}
+
+func (a ActivityPeriod) Contains(date time.Time) bool {
+	if date.Before(a.JoinedAt) {
+		return false
+	}
+
+	return a.WithdrawnAt.IsZero() || date.Before(a.WithdrawnAt)
+}
+
+func (m Membership) IsActive(date time.Time) bool {
+	for _, a := range m.Periods {
+		if a.Contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// Withdraw closes the open activity period of the partner in the company. The document, when
+// given, is the one registering the withdrawal, such as the amendment to the articles of association.
+func (p *Partner) Withdraw(companyID string, at time.Time, reason WithdrawalReason, notes string,
+	document *PartnerDocument) error {
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	return p.updateMembership(companyID, func(m *Membership) error {
+		n := len(m.Periods)
+		if n == 0 || !m.Periods[n-1].WithdrawnAt.IsZero() {
+			return ErrNotActive
+		}
+
+		last := &m.Periods[n-1]
+		if !at.After(last.JoinedAt) {
+			return ErrExitBeforeJoin
+		}
+
+		last.WithdrawnAt = at
+		last.WithdrawalReason = reason
+		last.WithdrawalNotes = notes
+
+		if document != nil {
+			if document.PartnerID != p.ID {
+				return ErrDocumentFromAnotherPartner
+			}
+			last.WithdrawalDocumentID = document.ID
+		}
+
+		return nil
+	})
+}
+
+// Rejoin opens a new activity period for a partner that withdrew from the company.
+func (p *Partner) Rejoin(companyID string, at time.Time) error {
+	return p.updateMembership(companyID, func(m *Membership) error {
+		n := len(m.Periods)
+		if n > 0 {
+			last := m.Periods[n-1]
+			if last.WithdrawnAt.IsZero() {
+				return ErrAlreadyActive
+			}
+
+			if at.Before(last.WithdrawnAt) {
+				return ErrBeforeLastExit
+			}
+		}
+
+		m.Periods = append(m.Periods, ActivityPeriod{JoinedAt: at})
+		return nil
+	})
+}
+
+func (p *Partner) updateMembership(companyID string, update func(m *Membership) error) error {
+	for i := range p.Memberships {
+		if p.Memberships[i].CompanyID != companyID {
+			continue
+		}
+
+		updated := *p
+		updated.Memberships = append([]Membership(nil), p.Memberships...)
+
+		m := &updated.Memberships[i]
+		m.Periods = append([]ActivityPeriod(nil), m.Periods...)
+		m.Roles = append([]RoleAssignment(nil), m.Roles...)
+		m.Holdings = append([]QuotaHolding(nil), m.Holdings...)
+
+		if err := update(m); err != nil {
+			return err
+		}
+
+		if err := validatePartner(updated); err != nil {
+			return err
+		}
+
+		*p = updated
+		return nil
+	}
+
+	return ErrNotMember
+}
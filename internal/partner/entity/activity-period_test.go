@@ -0,0 +1,64 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartner_Withdraw(t *testing.T) {
+	companyID := uuid.New().String()
+	joined := time.Date(2021, time.May, 10, 0, 0, 0, 0, time.UTC)
+	withdrawn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	rejoined := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	partner, err := entity.NewPartner("", companyID, "John", "Doe", true, joined)
+	require.Nil(t, err)
+
+	amendment, err := entity.NewDocument("", partner.ID, "Contract amendment", "", "pdf", time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	require.Equal(t, entity.ErrNotMember,
+		partner.Withdraw(uuid.New().String(), withdrawn, entity.WithdrawalVoluntary, "", &amendment))
+	require.Equal(t, entity.ErrExitBeforeJoin,
+		partner.Withdraw(companyID, joined, entity.WithdrawalVoluntary, "", &amendment))
+	require.Equal(t, errors.New("invalid fields: Partner.Memberships[0].Periods[0].WithdrawalReason: \"\""),
+		partner.Withdraw(companyID, withdrawn, "", "", &amendment))
+	require.Equal(t, entity.ErrAlreadyActive, partner.Rejoin(companyID, rejoined))
+
+	require.Nil(t, partner.Withdraw(companyID, withdrawn, entity.WithdrawalVoluntary, "Sold quotas to the other partners",
+		&amendment))
+	require.Equal(t, entity.ErrNotActive, partner.Withdraw(companyID, rejoined, entity.WithdrawalVoluntary, "", nil))
+	require.Equal(t, entity.ErrBeforeLastExit, partner.Rejoin(companyID, withdrawn.AddDate(0, 0, -1)))
+	require.Nil(t, partner.Rejoin(companyID, rejoined))
+
+	membership, ok := partner.MembershipIn(companyID)
+	require.True(t, ok)
+	require.Len(t, membership.Periods, 2)
+	require.Equal(t, amendment.ID, membership.Periods[0].WithdrawalDocumentID)
+
+	require.False(t, partner.IsActive(joined.AddDate(0, 0, -1)))
+	require.True(t, partner.IsActive(joined))
+	require.True(t, partner.IsActive(withdrawn.AddDate(0, 0, -1)))
+	require.False(t, partner.IsActive(withdrawn))
+	require.False(t, partner.IsActive(rejoined.AddDate(0, 0, -1)))
+	require.True(t, partner.IsActive(rejoined.AddDate(5, 0, 0)))
+}
+
+func TestPartner_WithdrawWithoutDate(t *testing.T) {
+	companyID := uuid.New().String()
+
+	partner, err := entity.NewPartner("", companyID, "John", "Doe", true, time.Date(2021, time.May, 10, 0, 0, 0, 0, time.UTC))
+	require.Nil(t, err)
+
+	require.Nil(t, partner.Withdraw(companyID, time.Time{}, entity.WithdrawalVoluntary, "", nil))
+
+	membership, ok := partner.MembershipIn(companyID)
+	require.True(t, ok)
+	require.False(t, membership.Periods[0].WithdrawnAt.IsZero())
+	require.False(t, partner.IsActive(time.Now().AddDate(0, 0, 1)))
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/taxid"
+)
+
+var (
+	ErrPartnerWithoutTaxID  = errors.New("partner tax ID is required to register payment details")
+	ErrHolderTaxIDMismatch  = errors.New("account holder tax ID does not match the partner tax ID")
+	ErrBankAccountNotFound  = valueobjects.ErrBankAccountNotFound
+	ErrDuplicatedPixKey     = valueobjects.ErrDuplicatedPixKey
+	ErrNoDefaultBankAccount = valueobjects.ErrNoDefaultBankAccount
+)
+
+type PartnerBankAccount = valueobjects.RegisteredBankAccount
+
+// AddBankAccount registers an account held by the partner. The first account, or one added
+// as default, becomes the account used for distributions.
+func (p *Partner) AddBankAccount(account valueobjects.BankAccount, isDefault bool) (PartnerBankAccount, error) {
+	if err := p.checkHolder(account.HolderTaxID); err != nil {
+		return PartnerBankAccount{}, err
+	}
+
+	updated := *p
+	var ba PartnerBankAccount
+	updated.BankAccounts, ba = valueobjects.AddBankAccount(p.BankAccounts, account, isDefault)
+
+	if err := validatePartner(updated); err != nil {
+		return PartnerBankAccount{}, err
+	}
+
+	*p = updated
+	return ba, nil
+}
+
+func (p *Partner) SetDefaultBankAccount(id string) error {
+	accounts, err := valueobjects.SetDefaultBankAccount(p.BankAccounts, id)
+	if err != nil {
+		return err
+	}
+
+	updated := *p
+	updated.BankAccounts = accounts
+
+	if err := validatePartner(updated); err != nil {
+		return err
+	}
+
+	*p = updated
+	return nil
+}
+
+func (p Partner) DefaultBankAccount() (PartnerBankAccount, error) {
+	return valueobjects.DefaultBankAccount(p.BankAccounts)
+}
+
+// AddPixKey registers a PIX key of the partner. CPF and CNPJ keys must be the partner's own tax ID.
+func (p *Partner) AddPixKey(keyType valueobjects.PixKeyType, key string) (valueobjects.PixKey, error) {
+	pix := valueobjects.NewPixKey(keyType, key)
+
+	if keyType == valueobjects.PixCPF || keyType == valueobjects.PixCNPJ {
+		if err := p.checkHolder(pix.Key); err != nil {
+			return valueobjects.PixKey{}, err
+		}
+	}
+
+	keys, err := valueobjects.AddPixKey(p.PixKeys, pix)
+	if err != nil {
+		return valueobjects.PixKey{}, err
+	}
+
+	updated := *p
+	updated.PixKeys = keys
+
+	if err := validatePartner(updated); err != nil {
+		return valueobjects.PixKey{}, err
+	}
+
+	*p = updated
+	return pix, nil
+}
+
+func (p Partner) checkHolder(holderTaxID string) error {
+	if p.TaxID == "" {
+		return ErrPartnerWithoutTaxID
+	}
+
+	if !taxid.Equal(p.TaxID, holderTaxID) {
+		return ErrHolderTaxIDMismatch
+	}
+
+	return nil
+}
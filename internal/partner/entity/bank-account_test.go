@@ -0,0 +1,156 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartner_AddBankAccount(t *testing.T) {
+	account := valueobjects.BankAccount{
+		BankCode:    "341",
+		Branch:      "0123",
+		Number:      "45678",
+		CheckDigit:  "9",
+		Type:        valueobjects.CheckingAccount,
+		HolderName:  "John Doe",
+		HolderTaxID: "52998224725",
+	}
+
+	withoutTaxID, err := entity.NewPartnerIdentity("", "John", "Doe", "", time.Time{})
+	require.Nil(t, err)
+	_, err = withoutTaxID.AddBankAccount(account, true)
+	require.Equal(t, entity.ErrPartnerWithoutTaxID, err)
+
+	partner, err := entity.NewPartnerIdentity("", "John", "Doe", "529.982.247-25", time.Time{})
+	require.Nil(t, err)
+
+	_, err = partner.DefaultBankAccount()
+	require.Equal(t, entity.ErrNoDefaultBankAccount, err)
+
+	other := account
+	other.HolderTaxID = "111.444.777-35"
+	_, err = partner.AddBankAccount(other, true)
+	require.Equal(t, entity.ErrHolderTaxIDMismatch, err)
+
+	invalid := account
+	invalid.BankCode = "34"
+	invalid.Type = "investment"
+	_, err = partner.AddBankAccount(invalid, true)
+	require.Equal(t, errors.New("invalid fields: Partner.BankAccounts[0].Account.BankCode: \"34\", "+
+		"Partner.BankAccounts[0].Account.Type: \"investment\""), err)
+
+	first, err := partner.AddBankAccount(account, false)
+	require.Nil(t, err)
+	require.True(t, first.Default)
+
+	savings := account
+	savings.Type = valueobjects.SavingsAccount
+	second, err := partner.AddBankAccount(savings, false)
+	require.Nil(t, err)
+	require.False(t, second.Default)
+
+	def, err := partner.DefaultBankAccount()
+	require.Nil(t, err)
+	require.Equal(t, first.ID, def.ID)
+
+	require.Equal(t, entity.ErrBankAccountNotFound, partner.SetDefaultBankAccount("unknown"))
+	require.Nil(t, partner.SetDefaultBankAccount(second.ID))
+
+	def, err = partner.DefaultBankAccount()
+	require.Nil(t, err)
+	require.Equal(t, second.ID, def.ID)
+}
+
+func TestPartner_AddPixKey(t *testing.T) {
+	type testCase struct {
+		test          string
+		keyType       valueobjects.PixKeyType
+		key           string
+		expectedKey   string
+		expectedError error
+	}
+
+	testsTable := []testCase{
+		{
+			test:        "Partner CPF key",
+			keyType:     valueobjects.PixCPF,
+			key:         "529.982.247-25",
+			expectedKey: "52998224725",
+		},
+		{
+			test:          "CPF key of someone else",
+			keyType:       valueobjects.PixCPF,
+			key:           "111.444.777-35",
+			expectedError: entity.ErrHolderTaxIDMismatch,
+		},
+		{
+			test:          "CNPJ key of a partner registered by CPF",
+			keyType:       valueobjects.PixCNPJ,
+			key:           "11.222.333/0001-81",
+			expectedError: entity.ErrHolderTaxIDMismatch,
+		},
+		{
+			test:        "Email key",
+			keyType:     valueobjects.PixEmail,
+			key:         " John.Doe@Example.com ",
+			expectedKey: "john.doe@example.com",
+		},
+		{
+			test:          "Invalid email key",
+			keyType:       valueobjects.PixEmail,
+			key:           "john.doe",
+			expectedError: errors.New("invalid fields: Partner.PixKeys[0].Key: \"john.doe\""),
+		},
+		{
+			test:        "Phone key",
+			keyType:     valueobjects.PixPhone,
+			key:         "+55 (11) 98765-4321",
+			expectedKey: "+5511987654321",
+		},
+		{
+			test:          "Phone key without country code",
+			keyType:       valueobjects.PixPhone,
+			key:           "(11) 98765-4321",
+			expectedError: errors.New("invalid fields: Partner.PixKeys[0].Key: \"+11987654321\""),
+		},
+		{
+			test:        "Random key",
+			keyType:     valueobjects.PixEVP,
+			key:         "123E4567-E89B-42D3-A456-426614174000",
+			expectedKey: "123e4567-e89b-42d3-a456-426614174000",
+		},
+		{
+			test:          "Random key that is not a UUID v4",
+			keyType:       valueobjects.PixEVP,
+			key:           "123e4567-e89b-12d3-a456-426614174000",
+			expectedError: errors.New("invalid fields: Partner.PixKeys[0].Key: \"123e4567-e89b-12d3-a456-426614174000\""),
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		partner, err := entity.NewPartnerIdentity("", "John", "Doe", "529.982.247-25", time.Time{})
+		require.Nil(t, err)
+
+		key, err := partner.AddPixKey(tc.keyType, tc.key)
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			require.Empty(t, partner.PixKeys)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedKey, key.Key)
+		require.Equal(t, []valueobjects.PixKey{key}, partner.PixKeys)
+
+		_, err = partner.AddPixKey(tc.keyType, tc.key)
+		require.Equal(t, entity.ErrDuplicatedPixKey, err)
+	}
+}
@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrOwnershipNotComplete = errors.New("company ownership must total 100%")
+	ErrEmptyCapTable        = errors.New("company has no quota holders on the given date")
+	ErrPercentageMismatch   = errors.New("holding percentage does not match its share of the quotas")
+)
+
+var fullOwnership = decimal.NewFromInt(100)
+
+// percentageTolerance absorbs the rounding of the percentages written in the articles of
+// association, such as 33.3334% for a third of the quotas.
+var percentageTolerance = decimal.New(1, -2)
+
+type CapTableEntry struct {
+	PartnerID     string
+	Quotas        int64
+	Capital       decimal.Decimal
+	Percentage    decimal.Decimal
+	EffectiveDate time.Time
+}
+
+type CapTable struct {
+	CompanyID    string
+	Date         time.Time
+	Entries      []CapTableEntry
+	TotalQuotas  int64
+	TotalCapital decimal.Decimal
+}
+
+// CapTableAt builds the cap table of a company in force on date from its quota holdings history.
+// Holdings of other companies are ignored.
+func CapTableAt(companyID string, holdings []QuotaHolding, date time.Time) (CapTable, error) {
+	current := make(map[string]QuotaHolding)
+
+	for _, h := range holdings {
+		if h.CompanyID != companyID || h.EffectiveDate.After(date) {
+			continue
+		}
+
+		if last, ok := current[h.PartnerID]; !ok || !h.EffectiveDate.Before(last.EffectiveDate) {
+			current[h.PartnerID] = h
+		}
+	}
+
+	table := CapTable{CompanyID: companyID, Date: date, TotalCapital: decimal.Zero}
+	total := decimal.Zero
+
+	for _, h := range current {
+		if h.Quotas == 0 {
+			continue
+		}
+
+		table.Entries = append(table.Entries, CapTableEntry{
+			PartnerID:     h.PartnerID,
+			Quotas:        h.Quotas,
+			Capital:       h.Capital(),
+			Percentage:    h.Percentage,
+			EffectiveDate: h.EffectiveDate,
+		})
+		table.TotalQuotas += h.Quotas
+		table.TotalCapital = table.TotalCapital.Add(h.Capital())
+		total = total.Add(h.Percentage)
+	}
+
+	sort.Slice(table.Entries, func(i, j int) bool {
+		if !table.Entries[i].Percentage.Equal(table.Entries[j].Percentage) {
+			return table.Entries[i].Percentage.GreaterThan(table.Entries[j].Percentage)
+		}
+		return table.Entries[i].PartnerID < table.Entries[j].PartnerID
+	})
+
+	if len(table.Entries) == 0 {
+		return table, ErrEmptyCapTable
+	}
+
+	if !total.Equal(fullOwnership) {
+		return table, fmt.Errorf("%w: %s%% on %s", ErrOwnershipNotComplete, total, date.Format(time.DateOnly))
+	}
+
+	for _, e := range table.Entries {
+		share := decimal.NewFromInt(e.Quotas).Div(decimal.NewFromInt(table.TotalQuotas)).Mul(fullOwnership)
+		if e.Percentage.Sub(share).Abs().GreaterThan(percentageTolerance) {
+			return table, fmt.Errorf("%w: %s has %s%% for %s%% of the quotas on %s", ErrPercentageMismatch,
+				e.PartnerID, e.Percentage, share.Round(4), date.Format(time.DateOnly))
+		}
+	}
+
+	return table, nil
+}
+
+// RegisterHoldings adds changes to the holdings of the partners once the cap table of each company
+// they change totals 100%, with percentages matching the quotas, after every change in its history.
+// partners must be every holder of those companies, so the changes of a rebalance are registered
+// together. No partner is changed if any change is rejected.
+func RegisterHoldings(partners []*Partner, changes ...QuotaHolding) error {
+	updated := make([]Partner, len(partners))
+	for i, p := range partners {
+		updated[i] = *p
+	}
+
+	companies := make(map[string]bool)
+	for _, h := range changes {
+		found := false
+		for i := range updated {
+			if updated[i].ID != h.PartnerID {
+				continue
+			}
+
+			if err := updated[i].addHolding(h); err != nil {
+				return err
+			}
+			found = true
+			break
+		}
+
+		if !found {
+			return ErrNotMember
+		}
+		companies[h.CompanyID] = true
+	}
+
+	var holdings []QuotaHolding
+	for _, p := range updated {
+		holdings = append(holdings, p.Holdings()...)
+	}
+
+	for companyID := range companies {
+		if err := ValidateCapTableHistory(companyID, holdings); err != nil {
+			return err
+		}
+	}
+
+	for i, p := range partners {
+		*p = updated[i]
+	}
+	return nil
+}
+
+// ValidateCapTableHistory checks that ownership totals 100% after every change in the company holdings.
+// A date where every partner left the company is accepted.
+func ValidateCapTableHistory(companyID string, holdings []QuotaHolding) error {
+	for _, h := range holdings {
+		if h.CompanyID != companyID {
+			continue
+		}
+
+		_, err := CapTableAt(companyID, holdings, h.EffectiveDate)
+		if err != nil && !errors.Is(err, ErrEmptyCapTable) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapTable_CapTableAt(t *testing.T) {
+	type entry struct {
+		partnerID  string
+		quotas     int64
+		percentage string
+	}
+
+	type testCase struct {
+		test            string
+		date            time.Time
+		expectedEntries []entry
+		expectedError   error
+	}
+
+	companyID := uuid.New().String()
+	otherCompanyID := uuid.New().String()
+	partnerA := "00000000-0000-0000-0000-00000000000a"
+	partnerB := "00000000-0000-0000-0000-00000000000b"
+	partnerC := "00000000-0000-0000-0000-00000000000c"
+
+	incorporation := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	contribution := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	brokenChange := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	holding := func(partnerID string, companyID string, quotas int64, percentage string, date time.Time) entity.QuotaHolding {
+		h, err := entity.NewQuotaHolding("", partnerID, companyID, quotas, decimal.NewFromInt(1),
+			decimal.RequireFromString(percentage), date, time.Time{})
+		require.Nil(t, err)
+		return h
+	}
+
+	holdings := []entity.QuotaHolding{
+		holding(partnerA, companyID, 50000, "50", incorporation),
+		holding(partnerB, companyID, 50000, "50", incorporation),
+		holding(partnerA, otherCompanyID, 10, "100", incorporation),
+		holding(partnerA, companyID, 50000, "33.3334", contribution),
+		holding(partnerB, companyID, 50000, "33.3333", contribution),
+		holding(partnerC, companyID, 50000, "33.3333", contribution),
+		holding(partnerC, companyID, 0, "0", brokenChange),
+	}
+
+	testsTable := []testCase{
+		{
+			test:          "Date before incorporation",
+			date:          incorporation.AddDate(0, 0, -1),
+			expectedError: entity.ErrEmptyCapTable,
+		},
+		{
+			test: "Cap table at incorporation",
+			date: incorporation,
+			expectedEntries: []entry{
+				{partnerID: partnerA, quotas: 50000, percentage: "50"},
+				{partnerID: partnerB, quotas: 50000, percentage: "50"},
+			},
+		},
+		{
+			test: "Cap table after a capital contribution",
+			date: contribution.AddDate(0, 1, 0),
+			expectedEntries: []entry{
+				{partnerID: partnerA, quotas: 50000, percentage: "33.3334"},
+				{partnerID: partnerB, quotas: 50000, percentage: "33.3333"},
+				{partnerID: partnerC, quotas: 50000, percentage: "33.3333"},
+			},
+		},
+		{
+			test: "Partner withdrawal without rebalancing the other stakes",
+			date: brokenChange,
+			expectedEntries: []entry{
+				{partnerID: partnerA, quotas: 50000, percentage: "33.3334"},
+				{partnerID: partnerB, quotas: 50000, percentage: "33.3333"},
+			},
+			expectedError: entity.ErrOwnershipNotComplete,
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		table, err := entity.CapTableAt(companyID, holdings, tc.date)
+
+		require.Len(t, table.Entries, len(tc.expectedEntries))
+		for i, e := range tc.expectedEntries {
+			require.Equal(t, e.partnerID, table.Entries[i].PartnerID)
+			require.Equal(t, e.quotas, table.Entries[i].Quotas)
+			require.True(t, decimal.RequireFromString(e.percentage).Equal(table.Entries[i].Percentage))
+		}
+
+		if tc.expectedError != nil {
+			require.True(t, errors.Is(err, tc.expectedError))
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+
+	require.True(t, errors.Is(entity.ValidateCapTableHistory(companyID, holdings), entity.ErrOwnershipNotComplete))
+	require.Nil(t, entity.ValidateCapTableHistory(companyID, holdings[:6]))
+}
+
+func TestCapTable_RegisterHoldings(t *testing.T) {
+	companyID := uuid.New().String()
+	incorporation := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	contribution := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+	john, err := entity.NewPartner("", companyID, "John", "Doe", true, incorporation)
+	require.Nil(t, err)
+	jane, err := entity.NewPartner("", companyID, "Jane", "Roe", true, incorporation)
+	require.Nil(t, err)
+	partners := []*entity.Partner{&john, &jane}
+
+	holding := func(partnerID string, quotas int64, percentage string, date time.Time) entity.QuotaHolding {
+		h, err := entity.NewQuotaHolding("", partnerID, companyID, quotas, decimal.NewFromInt(1),
+			decimal.RequireFromString(percentage), date, time.Time{})
+		require.Nil(t, err)
+		return h
+	}
+
+	require.True(t, errors.Is(entity.RegisterHoldings(partners, holding(john.ID, 50000, "50", incorporation)),
+		entity.ErrOwnershipNotComplete))
+	require.True(t, errors.Is(entity.RegisterHoldings(partners,
+		holding(john.ID, 60000, "50", incorporation), holding(jane.ID, 40000, "50", incorporation)),
+		entity.ErrPercentageMismatch))
+	require.Empty(t, john.Holdings())
+
+	require.Nil(t, entity.RegisterHoldings(partners,
+		holding(john.ID, 50000, "50", incorporation), holding(jane.ID, 50000, "50", incorporation)))
+
+	require.True(t, errors.Is(entity.RegisterHoldings(partners, holding(jane.ID, 100000, "66.6667", contribution)),
+		entity.ErrOwnershipNotComplete))
+	require.Len(t, jane.Holdings(), 1)
+
+	require.Nil(t, entity.RegisterHoldings(partners,
+		holding(john.ID, 50000, "33.3333", contribution), holding(jane.ID, 100000, "66.6667", contribution)))
+
+	table, err := entity.CapTableAt(companyID, append(john.Holdings(), jane.Holdings()...), contribution)
+	require.Nil(t, err)
+	require.Equal(t, jane.ID, table.Entries[0].PartnerID)
+}
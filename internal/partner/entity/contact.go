@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"strings"
+
+	notification "github.com/LHS-Real-Estate/cim-core/internal/notification/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+)
+
+const DefaultLanguage = "pt-BR"
+
+type Email struct {
+	Address string `validate:"required,email"`
+	Primary bool   `validate:"-"`
+}
+
+type Phone struct {
+	Number  string `validate:"required,e164,brphone"`
+	Primary bool   `validate:"-"`
+}
+
+type MailingAddress struct {
+	Label   string               `validate:"required,min=2"`
+	Address valueobjects.Address `validate:"required"`
+	Primary bool                 `validate:"-"`
+}
+
+// CommunicationPreferences holds the consent given by the partner to be contacted on each channel.
+// Every channel is opted out until the partner says otherwise.
+type CommunicationPreferences struct {
+	Language      string `validate:"required,oneof=pt-BR en-US es-ES"`
+	EmailOptIn    bool   `validate:"-"`
+	WhatsAppOptIn bool   `validate:"-"`
+	SMSOptIn      bool   `validate:"-"`
+}
+
+// NormalizePhone converts a Brazilian phone number such as "(11) 98765-4321" to E.164.
+func NormalizePhone(number string) string {
+	return valueobjects.NormalizePhone(number)
+}
+
+// IsMobile reports whether the number can receive WhatsApp and SMS messages.
+func (p Phone) IsMobile() bool {
+	return len(p.Number) == 14 && p.Number[5] == '9'
+}
+
+func (p *Partner) AddEmail(address string, primary bool) error {
+	updated := p.cloneContacts()
+	if primary {
+		for i := range updated.Emails {
+			updated.Emails[i].Primary = false
+		}
+	}
+	updated.Emails = append(updated.Emails, Email{Address: strings.TrimSpace(address), Primary: primary})
+
+	return p.applyContacts(updated)
+}
+
+func (p *Partner) AddPhone(number string, primary bool) error {
+	updated := p.cloneContacts()
+	if primary {
+		for i := range updated.Phones {
+			updated.Phones[i].Primary = false
+		}
+	}
+	updated.Phones = append(updated.Phones, Phone{Number: NormalizePhone(number), Primary: primary})
+
+	return p.applyContacts(updated)
+}
+
+func (p *Partner) AddAddress(label string, address valueobjects.Address, primary bool) error {
+	updated := p.cloneContacts()
+	if primary {
+		for i := range updated.Addresses {
+			updated.Addresses[i].Primary = false
+		}
+	}
+	updated.Addresses = append(updated.Addresses, MailingAddress{Label: label, Address: address, Primary: primary})
+
+	return p.applyContacts(updated)
+}
+
+func (p *Partner) SetPreferences(preferences CommunicationPreferences) error {
+	updated := *p
+	updated.Preferences = preferences
+
+	return p.applyContacts(updated)
+}
+
+func (p Partner) cloneContacts() Partner {
+	p.Emails = append([]Email(nil), p.Emails...)
+	p.Phones = append([]Phone(nil), p.Phones...)
+	p.Addresses = append([]MailingAddress(nil), p.Addresses...)
+	return p
+}
+
+func (p *Partner) applyContacts(updated Partner) error {
+	if err := validatePartner(updated); err != nil {
+		return err
+	}
+
+	*p = updated
+	return nil
+}
+
+// NotificationRecipient exposes the channels the partner opted in to, primary contacts first.
+// Phones are used for WhatsApp and SMS only when they are mobile numbers.
+func (p Partner) NotificationRecipient() notification.Recipient {
+	recipient := notification.Recipient{
+		ID:       p.ID,
+		Name:     strings.TrimSpace(p.Name + " " + p.Surname),
+		Language: p.Preferences.Language,
+	}
+
+	if p.Preferences.EmailOptIn {
+		for _, e := range primaryFirst(p.Emails, func(e Email) bool { return e.Primary }) {
+			recipient.Destinations = append(recipient.Destinations,
+				notification.Destination{Channel: notification.ChannelEmail, Address: e.Address})
+		}
+	}
+
+	phones := primaryFirst(p.Phones, func(ph Phone) bool { return ph.Primary })
+	for _, channel := range []struct {
+		optIn   bool
+		channel notification.Channel
+	}{
+		{p.Preferences.WhatsAppOptIn, notification.ChannelWhatsApp},
+		{p.Preferences.SMSOptIn, notification.ChannelSMS},
+	} {
+		if !channel.optIn {
+			continue
+		}
+
+		for _, ph := range phones {
+			if ph.IsMobile() {
+				recipient.Destinations = append(recipient.Destinations,
+					notification.Destination{Channel: channel.channel, Address: ph.Number})
+			}
+		}
+	}
+
+	return recipient
+}
+
+func primaryFirst[T any](items []T, isPrimary func(T) bool) []T {
+	sorted := make([]T, 0, len(items))
+	for _, item := range items {
+		if isPrimary(item) {
+			sorted = append(sorted, item)
+		}
+	}
+	for _, item := range items {
+		if !isPrimary(item) {
+			sorted = append(sorted, item)
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,112 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	notification "github.com/LHS-Real-Estate/cim-core/internal/notification/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartner_AddPhone(t *testing.T) {
+	type testCase struct {
+		test           string
+		number         string
+		expectedNumber string
+		expectedError  error
+	}
+
+	testsTable := []testCase{
+		{
+			test:           "Formatted mobile number",
+			number:         "(11) 98765-4321",
+			expectedNumber: "+5511987654321",
+		},
+		{
+			test:           "Landline number with trunk prefix",
+			number:         "0 (21) 3456-7890",
+			expectedNumber: "+552134567890",
+		},
+		{
+			test:           "Number already in E.164",
+			number:         "+55 47 99123 4567",
+			expectedNumber: "+5547991234567",
+		},
+		{
+			test:          "Foreign number error validation",
+			number:        "+1 202 555 0143",
+			expectedError: errors.New("invalid fields: Partner.Phones[0].Number: \"+12025550143\""),
+		},
+		{
+			test:          "Mobile number without the ninth digit error validation",
+			number:        "(11) 8765-432",
+			expectedError: errors.New("invalid fields: Partner.Phones[0].Number: \"+55118765432\""),
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		partner, err := entity.NewPartnerIdentity("", "John", "Doe", "", time.Time{})
+		require.Nil(t, err)
+
+		err = partner.AddPhone(tc.number, true)
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			require.Empty(t, partner.Phones)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedNumber, partner.Phones[0].Number)
+	}
+}
+
+func TestPartner_NotificationRecipient(t *testing.T) {
+	partner, err := entity.NewPartnerIdentity("", "John", "Doe", "", time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, entity.DefaultLanguage, partner.Preferences.Language)
+
+	require.Equal(t, errors.New("invalid fields: Partner.Emails[0].Address: \"john.doe\""), partner.AddEmail("john.doe", false))
+	require.Nil(t, partner.AddEmail("john@work.com", true))
+	require.Nil(t, partner.AddEmail("john@home.com", true))
+	require.Nil(t, partner.AddPhone("(11) 3456-7890", true))
+	require.Nil(t, partner.AddPhone("(11) 98765-4321", false))
+
+	address := valueobjects.Address{
+		Street:     "Avenida Paulista",
+		Number:     "1000",
+		District:   "Bela Vista",
+		City:       "São Paulo",
+		State:      "SP",
+		PostalCode: "01310-100",
+	}
+	require.Nil(t, partner.AddAddress("Home", address, true))
+
+	address.State = "XX"
+	require.Equal(t, errors.New("invalid fields: Partner.Addresses[1].Address.State: \"XX\""),
+		partner.AddAddress("Office", address, false))
+
+	require.False(t, partner.NotificationRecipient().Reachable())
+
+	require.Equal(t, errors.New("invalid fields: Partner.Preferences.Language: \"fr-FR\""),
+		partner.SetPreferences(entity.CommunicationPreferences{Language: "fr-FR"}))
+
+	require.Nil(t, partner.SetPreferences(entity.CommunicationPreferences{
+		Language:      "en-US",
+		EmailOptIn:    true,
+		WhatsAppOptIn: true,
+	}))
+
+	recipient := partner.NotificationRecipient()
+	require.Equal(t, partner.ID, recipient.ID)
+	require.Equal(t, "John Doe", recipient.Name)
+	require.Equal(t, "en-US", recipient.Language)
+	require.Equal(t, []string{"john@home.com", "john@work.com"}, recipient.AddressesFor(notification.ChannelEmail))
+	require.Equal(t, []string{"+5511987654321"}, recipient.AddressesFor(notification.ChannelWhatsApp))
+	require.Empty(t, recipient.AddressesFor(notification.ChannelSMS))
+}
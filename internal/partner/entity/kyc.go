@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/screening"
+)
+
+type KYCStatus string
+
+const (
+	KYCPending           KYCStatus = "pending"
+	KYCDocumentsReceived KYCStatus = "documents_received"
+	KYCScreened          KYCStatus = "screened"
+	KYCApproved          KYCStatus = "approved"
+	KYCRejected          KYCStatus = "rejected"
+)
+
+var (
+	ErrInvalidKYCTransition = errors.New("KYC status transition not allowed")
+	ErrKYCWithoutDocuments  = errors.New("KYC requires at least one partner document")
+	ErrKYCJustification     = errors.New("approving a partner with screening matches requires a justification")
+	ErrNoScreener           = errors.New("KYC screening requires a configured screener")
+)
+
+var allowedKYCTransitions = map[KYCStatus][]KYCStatus{
+	KYCPending:           {KYCDocumentsReceived},
+	KYCDocumentsReceived: {KYCScreened},
+	KYCScreened:          {KYCApproved, KYCRejected},
+	KYCApproved:          {KYCPending},
+	KYCRejected:          {KYCPending},
+}
+
+type KYCTransition struct {
+	From KYCStatus `validate:"required"`
+	To   KYCStatus `validate:"required"`
+	By   string    `validate:""`
+	At   time.Time `validate:"required"`
+}
+
+type KYCDecision struct {
+	Reviewer  string    `validate:"required,min=2"`
+	Approved  bool      `validate:"-"`
+	Notes     string    `validate:""`
+	DecidedAt time.Time `validate:"required"`
+}
+
+type KYC struct {
+	Status      KYCStatus         `validate:"required,oneof=pending documents_received screened approved rejected"`
+	DocumentIDs []string          `validate:"dive,uuid"`
+	Screening   *screening.Result `validate:"-"`
+	Decision    *KYCDecision      `validate:"omitempty"`
+	History     []KYCTransition   `validate:"dive"`
+}
+
+// ReceiveKYCDocuments registers the identity documents sent by the partner.
+func (p *Partner) ReceiveKYCDocuments(documents []PartnerDocument, at time.Time) error {
+	if len(documents) == 0 {
+		return ErrKYCWithoutDocuments
+	}
+
+	var ids []string
+	for _, d := range documents {
+		if d.PartnerID != p.ID {
+			return ErrDocumentFromAnotherPartner
+		}
+		ids = append(ids, d.ID)
+	}
+
+	return p.transitionKYC(KYCDocumentsReceived, "", at, func(k *KYC) error {
+		k.DocumentIDs = ids
+		return nil
+	})
+}
+
+// Screen checks the partner name and tax ID against the imported sanctions and PEP lists and
+// keeps the result as evidence for the reviewer.
+func (p *Partner) Screen(screener *screening.Screener, at time.Time) error {
+	if screener == nil {
+		return ErrNoScreener
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	result := screener.Screen(p.Name+" "+p.Surname, p.TaxID, at)
+
+	return p.transitionKYC(KYCScreened, "", at, func(k *KYC) error {
+		k.Screening = &result
+		return nil
+	})
+}
+
+// DecideKYC records the reviewer decision. Approving a partner with any screening match
+// requires notes explaining why the match was dismissed.
+func (p *Partner) DecideKYC(reviewer string, approved bool, notes string, at time.Time) error {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	to := KYCRejected
+	if approved {
+		to = KYCApproved
+	}
+
+	return p.transitionKYC(to, reviewer, at, func(k *KYC) error {
+		if approved && k.Screening != nil && !k.Screening.Clear() && notes == "" {
+			return ErrKYCJustification
+		}
+
+		k.Decision = &KYCDecision{Reviewer: reviewer, Approved: approved, Notes: notes, DecidedAt: at}
+		return nil
+	})
+}
+
+// RestartKYC sends the partner back to pending, keeping the history, so the partner can be screened again.
+func (p *Partner) RestartKYC(by string, at time.Time) error {
+	return p.transitionKYC(KYCPending, by, at, func(k *KYC) error {
+		k.DocumentIDs = nil
+		k.Screening = nil
+		k.Decision = nil
+		return nil
+	})
+}
+
+func (p *Partner) transitionKYC(to KYCStatus, by string, at time.Time, update func(k *KYC) error) error {
+	if !p.KYC.canTransitionTo(to) {
+		return fmt.Errorf("%w: from %s to %s", ErrInvalidKYCTransition, p.KYC.Status, to)
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	updated := *p
+	updated.KYC.History = append(append([]KYCTransition(nil), p.KYC.History...),
+		KYCTransition{From: p.KYC.Status, To: to, By: by, At: at})
+	updated.KYC.Status = to
+
+	if err := update(&updated.KYC); err != nil {
+		return err
+	}
+
+	if err := validatePartner(updated); err != nil {
+		return err
+	}
+
+	*p = updated
+	return nil
+}
+
+func (k KYC) canTransitionTo(to KYCStatus) bool {
+	for _, allowed := range allowedKYCTransitions[k.Status] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
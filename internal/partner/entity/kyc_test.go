@@ -0,0 +1,59 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/screening"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartner_KYC(t *testing.T) {
+	list, err := screening.ImportCSV(strings.NewReader("CPF;NOME\n***.982.247-**;JOHN DOE\n"), "CGU PEP",
+		screening.KindPEP, time.Now())
+	require.Nil(t, err)
+	screener := screening.NewScreener(0, list)
+
+	partner, err := entity.NewPartnerIdentity("", "John", "Doe", "529.982.247-25", time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, entity.KYCPending, partner.KYC.Status)
+
+	idCard, err := entity.NewDocument("", partner.ID, "Identity card", "", "pdf", time.Time{}, time.Time{})
+	require.Nil(t, err)
+	otherDoc, err := entity.NewDocument("", uuid.New().String(), "Identity card", "", "pdf", time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	require.True(t, errors.Is(partner.Screen(screener, time.Time{}), entity.ErrInvalidKYCTransition))
+	require.Equal(t, entity.ErrKYCWithoutDocuments, partner.ReceiveKYCDocuments(nil, time.Time{}))
+	require.Equal(t, entity.ErrDocumentFromAnotherPartner,
+		partner.ReceiveKYCDocuments([]entity.PartnerDocument{otherDoc}, time.Time{}))
+
+	require.Nil(t, partner.ReceiveKYCDocuments([]entity.PartnerDocument{idCard}, time.Time{}))
+	require.Equal(t, []string{idCard.ID}, partner.KYC.DocumentIDs)
+
+	require.Equal(t, entity.ErrNoScreener, partner.Screen(nil, time.Time{}))
+	require.Equal(t, entity.KYCDocumentsReceived, partner.KYC.Status)
+
+	require.Nil(t, partner.Screen(screener, time.Time{}))
+	require.Equal(t, entity.KYCScreened, partner.KYC.Status)
+	require.False(t, partner.KYC.Screening.Clear())
+
+	require.Equal(t, entity.ErrKYCJustification, partner.DecideKYC("compliance@lhs", true, "", time.Time{}))
+	require.Equal(t, errors.New("invalid fields: Partner.KYC.Decision.Reviewer: \"\""),
+		partner.DecideKYC("", false, "", time.Time{}))
+	require.Equal(t, entity.KYCScreened, partner.KYC.Status)
+
+	require.Nil(t, partner.DecideKYC("compliance@lhs", true, "Homonym, different birth date", time.Time{}))
+	require.Equal(t, entity.KYCApproved, partner.KYC.Status)
+	require.Equal(t, "compliance@lhs", partner.KYC.Decision.Reviewer)
+	require.Len(t, partner.KYC.History, 3)
+
+	require.Nil(t, partner.RestartKYC("compliance@lhs", time.Time{}))
+	require.Equal(t, entity.KYCPending, partner.KYC.Status)
+	require.Nil(t, partner.KYC.Screening)
+	require.Len(t, partner.KYC.History, 4)
+}
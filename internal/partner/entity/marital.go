@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+type MaritalStatus string
+
+const (
+	MaritalSingle      MaritalStatus = "single"
+	MaritalMarried     MaritalStatus = "married"
+	MaritalStableUnion MaritalStatus = "stable_union"
+	MaritalSeparated   MaritalStatus = "separated"
+	MaritalDivorced    MaritalStatus = "divorced"
+	MaritalWidowed     MaritalStatus = "widowed"
+)
+
+type PropertyRegime string
+
+const (
+	RegimePartialCommunity       PropertyRegime = "partial_community"
+	RegimeUniversalCommunity     PropertyRegime = "universal_community"
+	RegimeConventionalSeparation PropertyRegime = "conventional_separation"
+	RegimeMandatorySeparation    PropertyRegime = "mandatory_separation"
+	RegimeFinalParticipation     PropertyRegime = "final_participation"
+)
+
+type TransferKind string
+
+const (
+	TransferQuotas     TransferKind = "quotas"
+	TransferRealEstate TransferKind = "real_estate"
+)
+
+var (
+	ErrMaritalStatusUnknown = errors.New("partner marital status must be registered before any equity transfer")
+	ErrSpouseConsentMissing = errors.New("transfer requires the spouse consent document")
+)
+
+type Spouse struct {
+	Name  string `validate:"required,min=2"`
+	TaxID string `validate:"required,cpf"`
+}
+
+// MaritalInfo is required to be complete for married partners and partners in a stable union,
+// and a married partner must have the marriage certificate among the partner documents.
+type MaritalInfo struct {
+	Status                MaritalStatus  `validate:"required,oneof=single married stable_union separated divorced widowed"`
+	PropertyRegime        PropertyRegime `validate:"required_if=Status married,required_if=Status stable_union,omitempty,oneof=partial_community universal_community conventional_separation mandatory_separation final_participation"`
+	Spouse                *Spouse        `validate:"required_if=Status married,required_if=Status stable_union,omitempty"`
+	MarriageCertificateID string         `validate:"required_if=Status married,omitempty,uuid"`
+	UpdatedAt             time.Time      `validate:"required"`
+}
+
+func (p *Partner) SetMaritalInfo(status MaritalStatus, regime PropertyRegime, spouse *Spouse,
+	marriageCertificate *PartnerDocument, updatedAt time.Time) error {
+
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	info := MaritalInfo{Status: status, PropertyRegime: regime, Spouse: spouse, UpdatedAt: updatedAt}
+
+	if marriageCertificate != nil {
+		if marriageCertificate.PartnerID != p.ID {
+			return ErrDocumentFromAnotherPartner
+		}
+		info.MarriageCertificateID = marriageCertificate.ID
+	}
+
+	updated := *p
+	updated.Marital = &info
+
+	if err := validatePartner(updated); err != nil {
+		return err
+	}
+
+	*p = updated
+	return nil
+}
+
+// SpouseConsentRequired tells whether the spouse must co-sign a transfer made by the partner.
+//
+// Real estate can only be transferred without the spouse under conventional separation (Civil
+// Code art. 1647). Final participation may waive it in the prenuptial agreement, which we do not
+// track, so it is treated as requiring consent.
+//
+// Quotas are movable assets managed by the partner under final participation and conventional
+// separation. Under the community regimes, and mandatory separation where assets acquired during
+// the marriage are shared, the spouse co-signs.
+func (p Partner) SpouseConsentRequired(kind TransferKind) (bool, error) {
+	if p.Marital == nil {
+		return false, ErrMaritalStatusUnknown
+	}
+
+	if p.Marital.Status != MaritalMarried && p.Marital.Status != MaritalStableUnion {
+		return false, nil
+	}
+
+	switch p.Marital.PropertyRegime {
+	case RegimeConventionalSeparation:
+		return false, nil
+	case RegimeFinalParticipation:
+		return kind == TransferRealEstate, nil
+	default:
+		return true, nil
+	}
+}
+
+// CheckEquityTransfer must pass before registering a transfer of quotas or real estate by the
+// partner. The spouse consent document is only needed when the spouse must co-sign.
+func CheckEquityTransfer(p Partner, kind TransferKind, spouseConsent *PartnerDocument) error {
+	required, err := p.SpouseConsentRequired(kind)
+	if err != nil || !required {
+		return err
+	}
+
+	if spouseConsent == nil {
+		return ErrSpouseConsentMissing
+	}
+
+	if spouseConsent.PartnerID != p.ID {
+		return ErrDocumentFromAnotherPartner
+	}
+
+	return nil
+}
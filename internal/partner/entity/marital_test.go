@@ -0,0 +1,124 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartner_SetMaritalInfo(t *testing.T) {
+	partner, err := entity.NewPartnerIdentity("", "John", "Doe", "", time.Time{})
+	require.Nil(t, err)
+
+	certificate, err := entity.NewDocument("", partner.ID, "Marriage certificate", "", "pdf", time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	spouse := &entity.Spouse{Name: "Jane Doe", TaxID: "529.982.247-25"}
+
+	err = partner.SetMaritalInfo(entity.MaritalMarried, "", nil, nil, time.Time{})
+	require.Equal(t, errors.New("invalid fields: Partner.Marital.PropertyRegime: \"\", Partner.Marital.Spouse: \"<nil>\", "+
+		"Partner.Marital.MarriageCertificateID: \"\""), err)
+
+	err = partner.SetMaritalInfo(entity.MaritalMarried, entity.RegimePartialCommunity,
+		&entity.Spouse{Name: "Jane Doe", TaxID: "111.111.111-11"}, &certificate, time.Time{})
+	require.Equal(t, errors.New("invalid fields: Partner.Marital.Spouse.TaxID: \"111.111.111-11\""), err)
+	require.Nil(t, partner.Marital)
+
+	require.Nil(t, partner.SetMaritalInfo(entity.MaritalStableUnion, entity.RegimePartialCommunity, spouse, nil, time.Time{}))
+	require.Nil(t, partner.SetMaritalInfo(entity.MaritalSingle, "", nil, nil, time.Time{}))
+	require.Nil(t, partner.SetMaritalInfo(entity.MaritalMarried, entity.RegimePartialCommunity, spouse, &certificate,
+		time.Time{}))
+	require.Equal(t, certificate.ID, partner.Marital.MarriageCertificateID)
+}
+
+func TestPartner_CheckEquityTransfer(t *testing.T) {
+	type testCase struct {
+		test          string
+		status        entity.MaritalStatus
+		regime        entity.PropertyRegime
+		kind          entity.TransferKind
+		withConsent   bool
+		expectedError error
+	}
+
+	testsTable := []testCase{
+		{
+			test:          "Single partner transferring quotas",
+			status:        entity.MaritalSingle,
+			kind:          entity.TransferQuotas,
+			expectedError: nil,
+		},
+		{
+			test:          "Partial community without consent",
+			status:        entity.MaritalMarried,
+			regime:        entity.RegimePartialCommunity,
+			kind:          entity.TransferQuotas,
+			expectedError: entity.ErrSpouseConsentMissing,
+		},
+		{
+			test:          "Partial community with consent",
+			status:        entity.MaritalMarried,
+			regime:        entity.RegimePartialCommunity,
+			kind:          entity.TransferRealEstate,
+			withConsent:   true,
+			expectedError: nil,
+		},
+		{
+			test:          "Stable union under mandatory separation without consent",
+			status:        entity.MaritalStableUnion,
+			regime:        entity.RegimeMandatorySeparation,
+			kind:          entity.TransferQuotas,
+			expectedError: entity.ErrSpouseConsentMissing,
+		},
+		{
+			test:          "Conventional separation transferring real estate",
+			status:        entity.MaritalMarried,
+			regime:        entity.RegimeConventionalSeparation,
+			kind:          entity.TransferRealEstate,
+			expectedError: nil,
+		},
+		{
+			test:          "Final participation transferring quotas",
+			status:        entity.MaritalMarried,
+			regime:        entity.RegimeFinalParticipation,
+			kind:          entity.TransferQuotas,
+			expectedError: nil,
+		},
+		{
+			test:          "Final participation transferring real estate",
+			status:        entity.MaritalMarried,
+			regime:        entity.RegimeFinalParticipation,
+			kind:          entity.TransferRealEstate,
+			expectedError: entity.ErrSpouseConsentMissing,
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		partner, err := entity.NewPartnerIdentity("", "John", "Doe", "", time.Time{})
+		require.Nil(t, err)
+		require.Equal(t, entity.ErrMaritalStatusUnknown, entity.CheckEquityTransfer(partner, tc.kind, nil))
+
+		doc, err := entity.NewDocument("", partner.ID, "Partner document", "", "pdf", time.Time{}, time.Time{})
+		require.Nil(t, err)
+
+		var spouse *entity.Spouse
+		var certificate *entity.PartnerDocument
+		if tc.status == entity.MaritalMarried || tc.status == entity.MaritalStableUnion {
+			spouse = &entity.Spouse{Name: "Jane Doe", TaxID: "529.982.247-25"}
+			certificate = &doc
+		}
+		require.Nil(t, partner.SetMaritalInfo(tc.status, tc.regime, spouse, certificate, time.Time{}))
+
+		var consent *entity.PartnerDocument
+		if tc.withConsent {
+			consent = &doc
+		}
+
+		require.Equal(t, tc.expectedError, entity.CheckEquityTransfer(partner, tc.kind, consent))
+	}
+}
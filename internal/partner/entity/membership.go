@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+)
+
+// Membership is the participation of a partner in a company. The partner identity and
+// personal documents live in Partner, shared by every company the partner belongs to.
+type Membership struct {
+	ID        string           `validate:"required,uuid"`
+	PartnerID string           `validate:"required,uuid"`
+	CompanyID string           `validate:"required,uuid"`
+	Roles     []RoleAssignment `validate:"dive"`
+	Holdings  []QuotaHolding   `validate:"dive"`
+	Periods   []ActivityPeriod `validate:"dive"`
+	CreatedAt time.Time        `validate:"required"`
+}
+
+func NewMembership(id string, partnerID string, companyID string, joinedAt time.Time,
+	createdAt time.Time) (Membership, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	if joinedAt.IsZero() {
+		joinedAt = createdAt
+	}
+
+	m := Membership{
+		ID:        id,
+		PartnerID: partnerID,
+		CompanyID: companyID,
+		Periods:   []ActivityPeriod{{JoinedAt: joinedAt}},
+		CreatedAt: createdAt,
+	}
+
+	return m, validateMembership(m)
+}
+
+// StakeAt returns the quota holding of the membership in force on date.
+func (m Membership) StakeAt(date time.Time) (QuotaHolding, bool) {
+	var stake QuotaHolding
+	found := false
+
+	for _, h := range m.Holdings {
+		if h.EffectiveDate.After(date) {
+			continue
+		}
+
+		if !found || !h.EffectiveDate.Before(stake.EffectiveDate) {
+			stake = h
+			found = true
+		}
+	}
+
+	return stake, found
+}
+
+func validateMembership(m Membership) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(m)
+
+	return err
+}
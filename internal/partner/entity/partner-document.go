@@ -1,8 +1,6 @@
 package entity
 
 import (
-	"crypto/md5"
-	"encoding/hex"
 	"path"
 	"time"
 
@@ -60,6 +58,5 @@ func validatePartnerDoc(pd PartnerDocument) error {
 }
 
 func DocumentEncryptedName(docID string, extension string) string {
-	hash := md5.Sum([]byte(docID))
-	return hex.EncodeToString(hash[:]) + "." + extension
+	return valueobjects.DocumentEncryptedName(docID, extension)
 }
@@ -0,0 +1,257 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/taxid"
+)
+
+var (
+	ErrUnknownLegacyPartner = errors.New("legacy partner not found")
+	ErrConflictingTaxIDs    = errors.New("legacy partners merged as the same person have different tax IDs")
+	ErrOverlappingLegacy    = errors.New("legacy partners merged as the same person overlap in the same company")
+)
+
+// LegacyPartner is a partner record stored before memberships existed, when the same
+// person was registered once per company. TaxID is filled when the record can be matched
+// to the person's CPF or CNPJ. WithdrawnAt is the date an inactive partner left the company,
+// when it is known.
+type LegacyPartner struct {
+	ID          string
+	CompanyID   string
+	Name        string
+	Surname     string
+	TaxID       string
+	IsActive    bool
+	CreatedAt   time.Time
+	WithdrawnAt time.Time
+}
+
+// PossibleDuplicate lists partners that were kept apart but share the same name, to be
+// reviewed and, when they are the same person, migrated again with an explicit mapping.
+type PossibleDuplicate struct {
+	Name       string
+	PartnerIDs []string
+}
+
+type PartnerMigration struct {
+	Partners []Partner
+	// PartnerIDs maps every legacy partner ID to the ID of the partner it was merged into,
+	// so references such as PartnerDocument.PartnerID can be updated.
+	PartnerIDs         map[string]string
+	PossibleDuplicates []PossibleDuplicate
+	// UndatedWithdrawals lists the inactive legacy partner IDs with no withdrawal date, whose
+	// membership was closed when it was created. Their real withdrawal date is to be reviewed.
+	UndatedWithdrawals []string
+}
+
+// MigrateLegacyPartners merges legacy records of the same person into one partner with a
+// membership per company. Records are the same person only when their tax IDs match or when
+// samePerson, which maps a legacy partner ID to the legacy partner ID of the same person,
+// says so. Every other record becomes a partner of its own, and records sharing a name are
+// reported as possible duplicates. The oldest record keeps its ID as the partner ID and every
+// record keeps its ID as the membership ID. Inactive records become a membership whose activity
+// period is closed at the legacy withdrawal date, with an unknown reason, or at its creation
+// date, and no role, when the record has no withdrawal date. Records of the same
+// person in the same company become activity periods of one membership, which keeps the ID of
+// the oldest record, and must not overlap.
+func MigrateLegacyPartners(legacy []LegacyPartner, samePerson map[string]string) (PartnerMigration, error) {
+	records := append([]LegacyPartner(nil), legacy...)
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	groups, err := groupLegacyPartners(records, samePerson)
+	if err != nil {
+		return PartnerMigration{}, err
+	}
+
+	migration := PartnerMigration{PartnerIDs: make(map[string]string)}
+	byGroup := make(map[string]int)
+
+	for _, r := range records {
+		group := groups.find(r.ID)
+
+		idx, ok := byGroup[group]
+		if !ok {
+			p, err := NewPartnerIdentity(r.ID, r.Name, r.Surname, groups.taxIDs[group], r.CreatedAt)
+			if err != nil {
+				return PartnerMigration{}, err
+			}
+			p.CompanyID = r.CompanyID
+
+			migration.Partners = append(migration.Partners, p)
+			idx = len(migration.Partners) - 1
+			byGroup[group] = idx
+		}
+
+		p := &migration.Partners[idx]
+
+		m, err := NewMembership(r.ID, p.ID, r.CompanyID, r.CreatedAt, r.CreatedAt)
+		if err != nil {
+			return PartnerMigration{}, err
+		}
+
+		role := RoleAssignment{Role: RoleQuotaHolder, StartDate: r.CreatedAt}
+		switch {
+		case r.IsActive:
+			m.Roles = append(m.Roles, role)
+		case r.WithdrawnAt.IsZero():
+			m.Periods[0].WithdrawnAt = r.CreatedAt
+			m.Periods[0].WithdrawalReason = WithdrawalUnknown
+			migration.UndatedWithdrawals = append(migration.UndatedWithdrawals, r.ID)
+		default:
+			m.Periods[0].WithdrawnAt = r.WithdrawnAt
+			m.Periods[0].WithdrawalReason = WithdrawalUnknown
+			role.EndDate = r.WithdrawnAt
+			m.Roles = append(m.Roles, role)
+		}
+
+		if err := mergeLegacyMembership(p, m); err != nil {
+			return PartnerMigration{}, fmt.Errorf("%w: %s", err, r.ID)
+		}
+		migration.PartnerIDs[r.ID] = p.ID
+	}
+
+	for _, p := range migration.Partners {
+		if err := validatePartner(p); err != nil {
+			return PartnerMigration{}, err
+		}
+	}
+
+	migration.PossibleDuplicates = possibleDuplicates(migration.Partners)
+
+	return migration, nil
+}
+
+// mergeLegacyMembership adds the membership of a legacy record to the partner or, when the
+// partner is already a member of the company, appends its period and role to that membership.
+// Records come oldest first, so the earlier period must be closed before the new one starts.
+func mergeLegacyMembership(p *Partner, m Membership) error {
+	for i := range p.Memberships {
+		existing := &p.Memberships[i]
+		if existing.CompanyID != m.CompanyID {
+			continue
+		}
+
+		last := existing.Periods[len(existing.Periods)-1]
+		if last.WithdrawnAt.IsZero() || m.Periods[0].JoinedAt.Before(last.WithdrawnAt) {
+			return ErrOverlappingLegacy
+		}
+
+		existing.Periods = append(existing.Periods, m.Periods...)
+		existing.Roles = append(existing.Roles, m.Roles...)
+		return nil
+	}
+
+	p.Memberships = append(p.Memberships, m)
+	return nil
+}
+
+// legacyGroups is a union-find of the legacy partner IDs of the same person.
+type legacyGroups struct {
+	parent map[string]string
+	taxIDs map[string]string
+}
+
+func (g legacyGroups) find(id string) string {
+	for g.parent[id] != id {
+		id = g.parent[id]
+	}
+	return id
+}
+
+func (g legacyGroups) union(a string, b string) error {
+	ra, rb := g.find(a), g.find(b)
+	if ra == rb {
+		return nil
+	}
+
+	ta, tb := g.taxIDs[ra], g.taxIDs[rb]
+	if ta != "" && tb != "" && ta != tb {
+		return fmt.Errorf("%w: %s and %s", ErrConflictingTaxIDs, a, b)
+	}
+
+	g.parent[rb] = ra
+	if ta == "" {
+		g.taxIDs[ra] = tb
+	}
+	delete(g.taxIDs, rb)
+	return nil
+}
+
+func groupLegacyPartners(records []LegacyPartner, samePerson map[string]string) (legacyGroups, error) {
+	groups := legacyGroups{parent: make(map[string]string), taxIDs: make(map[string]string)}
+	for _, r := range records {
+		groups.parent[r.ID] = r.ID
+		if r.TaxID != "" {
+			groups.taxIDs[r.ID] = taxid.Normalize(r.TaxID)
+		}
+	}
+
+	byTaxID := make(map[string]string)
+	for _, r := range records {
+		if r.TaxID == "" {
+			continue
+		}
+
+		key := taxid.Normalize(r.TaxID)
+		if first, ok := byTaxID[key]; ok {
+			if err := groups.union(first, r.ID); err != nil {
+				return legacyGroups{}, err
+			}
+			continue
+		}
+		byTaxID[key] = r.ID
+	}
+
+	for id, other := range samePerson {
+		_, okID := groups.parent[id]
+		_, okOther := groups.parent[other]
+		if !okID || !okOther {
+			return legacyGroups{}, fmt.Errorf("%w: %s -> %s", ErrUnknownLegacyPartner, id, other)
+		}
+
+		if err := groups.union(other, id); err != nil {
+			return legacyGroups{}, err
+		}
+	}
+
+	return groups, nil
+}
+
+func possibleDuplicates(partners []Partner) []PossibleDuplicate {
+	var duplicates []PossibleDuplicate
+	byName := make(map[string]int)
+
+	for _, p := range partners {
+		key := personNameKey(p.Name, p.Surname)
+
+		idx, ok := byName[key]
+		if !ok {
+			byName[key] = len(duplicates)
+			duplicates = append(duplicates, PossibleDuplicate{
+				Name:       strings.TrimSpace(strings.TrimSpace(p.Name) + " " + strings.TrimSpace(p.Surname)),
+				PartnerIDs: []string{p.ID},
+			})
+			continue
+		}
+		duplicates[idx].PartnerIDs = append(duplicates[idx].PartnerIDs, p.ID)
+	}
+
+	var reported []PossibleDuplicate
+	for _, d := range duplicates {
+		if len(d.PartnerIDs) > 1 {
+			reported = append(reported, d)
+		}
+	}
+	return reported
+}
+
+func personNameKey(name string, surname string) string {
+	return strings.ToLower(strings.TrimSpace(name)) + "|" + strings.ToLower(strings.TrimSpace(surname))
+}
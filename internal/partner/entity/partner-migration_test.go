@@ -0,0 +1,124 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartner_MigrateLegacyPartners(t *testing.T) {
+	companyA := uuid.New().String()
+	companyB := uuid.New().String()
+	timeNow := time.Now()
+
+	legacy := []entity.LegacyPartner{
+		{ID: uuid.New().String(), CompanyID: companyB, Name: "John", Surname: "Doe", TaxID: "52998224725", IsActive: false, CreatedAt: timeNow.AddDate(0, -6, 0), WithdrawnAt: timeNow.AddDate(0, -1, 0)},
+		{ID: uuid.New().String(), CompanyID: companyA, Name: "john ", Surname: "DOE", TaxID: "529.982.247-25", IsActive: true, CreatedAt: timeNow.AddDate(-1, 0, 0)},
+		{ID: uuid.New().String(), CompanyID: companyA, Name: "Mary", Surname: "Doe", IsActive: true, CreatedAt: timeNow},
+		{ID: uuid.New().String(), CompanyID: companyB, Name: "Mary", Surname: "Doe", IsActive: true, CreatedAt: timeNow},
+	}
+
+	migration, err := entity.MigrateLegacyPartners(legacy, nil)
+	require.Nil(t, err)
+	require.Len(t, migration.Partners, 3)
+
+	john := migration.Partners[0]
+	require.Equal(t, legacy[1].ID, john.ID)
+	require.Equal(t, "52998224725", john.TaxID)
+	require.Equal(t, legacy[1].CreatedAt, john.CreatedAt)
+	require.Equal(t, companyA, john.CompanyID)
+	require.Len(t, john.Memberships, 2)
+	require.True(t, john.IsActive(timeNow))
+
+	membershipA, ok := john.MembershipIn(companyA)
+	require.True(t, ok)
+	require.Equal(t, legacy[1].ID, membershipA.ID)
+	require.True(t, membershipA.IsActive(timeNow))
+
+	membershipB, ok := john.MembershipIn(companyB)
+	require.True(t, ok)
+	require.Equal(t, legacy[0].ID, membershipB.ID)
+	require.Equal(t, john.ID, membershipB.PartnerID)
+	require.False(t, membershipB.IsActive(timeNow))
+	require.True(t, membershipB.IsActive(timeNow.AddDate(0, -2, 0)))
+	require.Len(t, membershipB.Periods, 1)
+	require.Equal(t, legacy[0].WithdrawnAt, membershipB.Periods[0].WithdrawnAt)
+	require.Equal(t, entity.WithdrawalUnknown, membershipB.Periods[0].WithdrawalReason)
+	require.Nil(t, john.Rejoin(companyB, timeNow))
+	require.True(t, john.IsActive(timeNow))
+
+	require.Equal(t, legacy[2].ID, migration.Partners[1].ID)
+	require.Equal(t, legacy[3].ID, migration.Partners[2].ID)
+	require.Equal(t, []entity.PossibleDuplicate{{Name: "Mary Doe", PartnerIDs: []string{legacy[2].ID, legacy[3].ID}}},
+		migration.PossibleDuplicates)
+
+	require.Equal(t, map[string]string{
+		legacy[0].ID: john.ID,
+		legacy[1].ID: john.ID,
+		legacy[2].ID: legacy[2].ID,
+		legacy[3].ID: legacy[3].ID,
+	}, migration.PartnerIDs)
+
+	migration, err = entity.MigrateLegacyPartners(legacy, map[string]string{legacy[3].ID: legacy[2].ID})
+	require.Nil(t, err)
+	require.Len(t, migration.Partners, 2)
+	require.Empty(t, migration.PossibleDuplicates)
+
+	mary := migration.Partners[1]
+	require.Len(t, mary.Memberships, 2)
+	require.Equal(t, mary.ID, migration.PartnerIDs[legacy[3].ID])
+
+	_, err = entity.MigrateLegacyPartners(legacy, map[string]string{legacy[2].ID: uuid.New().String()})
+	require.True(t, errors.Is(err, entity.ErrUnknownLegacyPartner))
+
+	undated := append([]entity.LegacyPartner(nil), legacy...)
+	undated[0].WithdrawnAt = time.Time{}
+	migration, err = entity.MigrateLegacyPartners(undated, nil)
+	require.Nil(t, err)
+	require.Equal(t, []string{legacy[0].ID}, migration.UndatedWithdrawals)
+	membershipB, ok = migration.Partners[0].MembershipIn(companyB)
+	require.True(t, ok)
+	require.Equal(t, legacy[0].CreatedAt, membershipB.Periods[0].WithdrawnAt)
+	require.Equal(t, entity.WithdrawalUnknown, membershipB.Periods[0].WithdrawalReason)
+	require.Empty(t, membershipB.Roles)
+	require.False(t, membershipB.IsActive(legacy[0].CreatedAt))
+	require.Nil(t, migration.Partners[0].Rejoin(companyB, timeNow))
+
+	conflicting := append([]entity.LegacyPartner(nil), legacy...)
+	conflicting[2].TaxID = "111.444.777-35"
+	_, err = entity.MigrateLegacyPartners(conflicting, map[string]string{legacy[2].ID: legacy[1].ID})
+	require.True(t, errors.Is(err, entity.ErrConflictingTaxIDs))
+
+	returned := append([]entity.LegacyPartner(nil), legacy...)
+	returned[2].CompanyID = companyB
+	returned[2].CreatedAt = timeNow.AddDate(0, -1, 0)
+	returned[2].TaxID = "52998224725"
+	returned[3].CreatedAt = timeNow.AddDate(-2, 0, 0)
+	migration, err = entity.MigrateLegacyPartners(returned, nil)
+	require.Nil(t, err)
+	require.Len(t, migration.Partners, 2)
+
+	john = migration.Partners[1]
+	require.Len(t, john.Memberships, 2)
+	membershipB, ok = john.MembershipIn(companyB)
+	require.True(t, ok)
+	require.Equal(t, returned[0].ID, membershipB.ID)
+	require.Len(t, membershipB.Periods, 2)
+	require.Len(t, membershipB.Roles, 2)
+	require.True(t, membershipB.IsActive(timeNow))
+	require.Equal(t, john.ID, migration.PartnerIDs[returned[2].ID])
+
+	overlapping := append([]entity.LegacyPartner(nil), returned...)
+	overlapping[2].CreatedAt = timeNow.AddDate(0, -3, 0)
+	_, err = entity.MigrateLegacyPartners(overlapping, nil)
+	require.True(t, errors.Is(err, entity.ErrOverlappingLegacy))
+
+	invalid := append([]entity.LegacyPartner(nil), legacy...)
+	invalid[0].WithdrawnAt = invalid[0].CreatedAt.AddDate(0, 0, -1)
+	_, err = entity.MigrateLegacyPartners(invalid, nil)
+	require.ErrorContains(t, err, "Periods[0].WithdrawnAt")
+}
@@ -3,21 +3,65 @@ package entity
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"errors"
 	"time"
 
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
 	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrAlreadyMember = errors.New("partner is already a member of the company")
+	ErrNotMember     = errors.New("partner is not a member of the company")
+)
+
 type Partner struct {
-	ID        string    `validate:"required,uuid"`
-	CompanyID string    `validate:"required,uuid"`
-	Name      string    `validate:"required,min=2"`
-	Surname   string    `validate:"omitempty,min=3"`
-	IsActive  bool      `validate:"-"`
-	CreatedAt time.Time `validate:"required"`
+	ID string `validate:"required,uuid"`
+	// Deprecated: CompanyID is the company of the first membership, kept for callers written
+	// before partners could belong to several companies. Use Memberships instead.
+	CompanyID    string                   `validate:"omitempty,uuid"`
+	Name         string                   `validate:"required,min=2"`
+	Surname      string                   `validate:"omitempty,min=3"`
+	TaxID        string                   `validate:"omitempty,taxid"`
+	Memberships  []Membership             `validate:"dive"`
+	Emails       []Email                  `validate:"dive"`
+	Phones       []Phone                  `validate:"dive"`
+	Addresses    []MailingAddress         `validate:"dive"`
+	Preferences  CommunicationPreferences `validate:""`
+	KYC          KYC                      `validate:""`
+	Marital      *MaritalInfo             `validate:"omitempty"`
+	BankAccounts []PartnerBankAccount     `validate:"dive"`
+	PixKeys      []valueobjects.PixKey    `validate:"dive"`
+	CreatedAt    time.Time                `validate:"required"`
 }
 
+// NewPartnerIdentity creates a partner that does not belong to any company yet.
+func NewPartnerIdentity(id string, name string, surname string, taxID string, createdAt time.Time) (Partner, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	p := Partner{
+		ID:          id,
+		Name:        name,
+		Surname:     surname,
+		TaxID:       taxID,
+		Preferences: CommunicationPreferences{Language: DefaultLanguage},
+		KYC:         KYC{Status: KYCPending},
+		CreatedAt:   createdAt,
+	}
+
+	return p, validatePartner(p)
+}
+
+// NewPartner creates a partner that is a quota holder of companyID, active since createdAt
+// when isActive is set. It is kept for callers written before partners could belong to several companies.
 func NewPartner(id string, companyID string, name string, surname string, isActive bool, createdAt time.Time) (Partner, error) {
 
 	if id == "" {
@@ -29,17 +73,88 @@ func NewPartner(id string, companyID string, name string, surname string, isActi
 	}
 
 	p := Partner{
-		ID:        id,
+		ID:          id,
+		CompanyID:   companyID,
+		Name:        name,
+		Surname:     surname,
+		Preferences: CommunicationPreferences{Language: DefaultLanguage},
+		KYC:         KYC{Status: KYCPending},
+		CreatedAt:   createdAt,
+	}
+
+	m := Membership{
+		ID:        uuid.New().String(),
+		PartnerID: id,
 		CompanyID: companyID,
-		Name:      name,
-		Surname:   surname,
-		IsActive:  isActive,
+		Roles:     []RoleAssignment{{Role: RoleQuotaHolder, StartDate: createdAt}},
 		CreatedAt: createdAt,
 	}
 
+	if isActive {
+		m.Periods = append(m.Periods, ActivityPeriod{JoinedAt: createdAt})
+	}
+
+	p.Memberships = append(p.Memberships, m)
+
 	return p, validatePartner(p)
 }
 
+// IsActive reports whether the partner is active in at least one company on date.
+func (p Partner) IsActive(date time.Time) bool {
+	for _, m := range p.Memberships {
+		if m.IsActive(date) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Partner) MembershipIn(companyID string) (Membership, bool) {
+	for _, m := range p.Memberships {
+		if m.CompanyID == companyID {
+			return m, true
+		}
+	}
+	return Membership{}, false
+}
+
+func (p *Partner) Join(companyID string, joinedAt time.Time) (Membership, error) {
+	if _, ok := p.MembershipIn(companyID); ok {
+		return Membership{}, ErrAlreadyMember
+	}
+
+	m, err := NewMembership("", p.ID, companyID, joinedAt, time.Time{})
+	if err != nil {
+		return m, err
+	}
+
+	p.Memberships = append(p.Memberships, m)
+	if p.CompanyID == "" {
+		p.CompanyID = companyID
+	}
+	return m, nil
+}
+
+func (p *Partner) addHolding(h QuotaHolding) error {
+	if h.PartnerID != p.ID {
+		return ErrNotMember
+	}
+
+	return p.updateMembership(h.CompanyID, func(m *Membership) error {
+		m.Holdings = append(m.Holdings, h)
+		return nil
+	})
+}
+
+// Holdings returns the quota holdings of the partner in every company, ready to be used with CapTableAt.
+func (p Partner) Holdings() []QuotaHolding {
+	var holdings []QuotaHolding
+	for _, m := range p.Memberships {
+		holdings = append(holdings, m.Holdings...)
+	}
+	return holdings
+}
+
 func validatePartner(c Partner) error {
 	cv := validator.NewCustomValidate()
 	err := cv.Validate(c)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,7 +37,7 @@ func TestPartner_NewPartner(t *testing.T) {
 			test:           "Empty CompanyID and Name error validation",
 			input:          input_output{id: testId, createdAt: timeNow},
 			expectedOutput: input_output{id: testId, createdAt: timeNow},
-			expectedError:  errors.New("invalid fields: Partner.CompanyID: \"\", Partner.Name: \"\""),
+			expectedError:  errors.New("invalid fields: Partner.Name: \"\", Partner.Memberships[0].CompanyID: \"\""),
 		},
 		{
 			test: "Company Name and Surname length error validation",
@@ -76,7 +77,7 @@ func TestPartner_NewPartner(t *testing.T) {
 				isActive:  true,
 				createdAt: timeNow,
 			},
-			expectedError: errors.New("invalid fields: Partner.ID: \"Invalid UUID\", Partner.CompanyID: \"Invalid UUID\""),
+			expectedError: errors.New("invalid fields: Partner.ID: \"Invalid UUID\", Partner.CompanyID: \"Invalid UUID\", Partner.Memberships[0].PartnerID: \"Invalid UUID\", Partner.Memberships[0].CompanyID: \"Invalid UUID\""),
 		},
 		{
 			test: "Valid Company fields generating new ID and CreatedAt when empty",
@@ -138,7 +139,7 @@ func TestPartner_NewPartner(t *testing.T) {
 		require.Equal(t, tc.expectedOutput.companyID, partner.CompanyID)
 		require.Equal(t, tc.expectedOutput.name, partner.Name)
 		require.Equal(t, tc.expectedOutput.surname, partner.Surname)
-		require.Equal(t, tc.expectedOutput.isActive, partner.IsActive)
+		require.Equal(t, tc.expectedOutput.isActive, partner.IsActive(partner.CreatedAt))
 
 		require.NotZero(t, partner.CreatedAt)
 
@@ -154,3 +155,44 @@ func TestPartner_NewPartner(t *testing.T) {
 		require.Nil(t, err)
 	}
 }
+
+func TestPartner_Join(t *testing.T) {
+	companyA := uuid.New().String()
+	companyB := uuid.New().String()
+
+	partner, err := entity.NewPartnerIdentity("", "John", "Doe", "529.982.247-25", time.Time{})
+	require.Nil(t, err)
+	require.Empty(t, partner.Memberships)
+	require.False(t, partner.IsActive(partner.CreatedAt))
+
+	_, err = entity.NewPartnerIdentity("", "John", "Doe", "529.982.247-24", time.Time{})
+	require.Equal(t, errors.New("invalid fields: Partner.TaxID: \"529.982.247-24\""), err)
+
+	_, err = partner.Join(companyA, time.Time{})
+	require.Nil(t, err)
+	_, err = partner.Join(companyB, time.Time{})
+	require.Nil(t, err)
+	_, err = partner.Join(companyA, time.Time{})
+	require.Equal(t, entity.ErrAlreadyMember, err)
+
+	require.Len(t, partner.Memberships, 2)
+	require.True(t, partner.IsActive(time.Now()))
+
+	holding, err := entity.NewQuotaHolding("", partner.ID, companyB, 10, decimal.NewFromInt(1),
+		decimal.NewFromInt(100), time.Now(), time.Time{})
+	require.Nil(t, err)
+	require.Nil(t, entity.RegisterHoldings([]*entity.Partner{&partner}, holding))
+
+	outsider, err := entity.NewQuotaHolding("", partner.ID, uuid.New().String(), 10, decimal.NewFromInt(1),
+		decimal.NewFromInt(100), time.Now(), time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, entity.ErrNotMember, entity.RegisterHoldings([]*entity.Partner{&partner}, outsider))
+
+	membership, ok := partner.MembershipIn(companyB)
+	require.True(t, ok)
+
+	stake, ok := membership.StakeAt(time.Now())
+	require.True(t, ok)
+	require.Equal(t, holding, stake)
+	require.Equal(t, []entity.QuotaHolding{holding}, partner.Holdings())
+}
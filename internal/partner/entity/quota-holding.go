@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// QuotaHolding is the position of a partner in a company from EffectiveDate on.
+// It is superseded by the next holding of the same partner in the same company, and a
+// holding with no quotas records that the partner left the company.
+type QuotaHolding struct {
+	ID            string          `validate:"required,uuid"`
+	PartnerID     string          `validate:"required,uuid"`
+	CompanyID     string          `validate:"required,uuid"`
+	Quotas        int64           `validate:"gte=0"`
+	NominalValue  decimal.Decimal `validate:"gt=0"`
+	Percentage    decimal.Decimal `validate:"gte=0,lte=100"`
+	EffectiveDate time.Time       `validate:"required"`
+	CreatedAt     time.Time       `validate:"required"`
+}
+
+func NewQuotaHolding(id string, partnerID string, companyID string, quotas int64, nominalValue decimal.Decimal,
+	percentage decimal.Decimal, effectiveDate time.Time, createdAt time.Time) (QuotaHolding, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	h := QuotaHolding{
+		ID:            id,
+		PartnerID:     partnerID,
+		CompanyID:     companyID,
+		Quotas:        quotas,
+		NominalValue:  nominalValue,
+		Percentage:    percentage,
+		EffectiveDate: effectiveDate,
+		CreatedAt:     createdAt,
+	}
+
+	return h, validateQuotaHolding(h)
+}
+
+// Capital is the subscribed capital represented by the holding.
+func (h QuotaHolding) Capital() decimal.Decimal {
+	return h.NominalValue.Mul(decimal.NewFromInt(h.Quotas))
+}
+
+func validateQuotaHolding(h QuotaHolding) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(h)
+
+	return err
+}
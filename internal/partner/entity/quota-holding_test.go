@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaHolding_NewQuotaHolding(t *testing.T) {
+	type input struct {
+		partnerID     string
+		companyID     string
+		quotas        int64
+		nominalValue  decimal.Decimal
+		percentage    decimal.Decimal
+		effectiveDate time.Time
+	}
+
+	type testCase struct {
+		test            string
+		input           input
+		expectedCapital decimal.Decimal
+		expectedError   error
+	}
+
+	testId := uuid.New().String()
+	timeNow := time.Now()
+
+	testsTable := []testCase{
+		{
+			test: "Empty IDs, negative quotas and percentage above 100 error validation",
+			input: input{
+				quotas:        -1,
+				nominalValue:  decimal.NewFromInt(1),
+				percentage:    decimal.NewFromInt(101),
+				effectiveDate: timeNow,
+			},
+			expectedCapital: decimal.NewFromInt(-1),
+			expectedError:   errors.New("invalid fields: QuotaHolding.PartnerID: \"\", QuotaHolding.CompanyID: \"\", QuotaHolding.Quotas: \"-1\", QuotaHolding.Percentage: \"101\""),
+		},
+		{
+			test: "Zero nominal value and empty effective date error validation",
+			input: input{
+				partnerID:  testId,
+				companyID:  testId,
+				quotas:     1000,
+				percentage: decimal.NewFromInt(50),
+			},
+			expectedCapital: decimal.Zero,
+			expectedError:   errors.New("invalid fields: QuotaHolding.NominalValue: \"0\", QuotaHolding.EffectiveDate: \"0001-01-01 00:00:00 +0000 UTC\""),
+		},
+		{
+			test: "Valid QuotaHolding fields",
+			input: input{
+				partnerID:     testId,
+				companyID:     testId,
+				quotas:        1000,
+				nominalValue:  decimal.RequireFromString("1.50"),
+				percentage:    decimal.NewFromInt(50),
+				effectiveDate: timeNow,
+			},
+			expectedCapital: decimal.NewFromInt(1500),
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		holding, err := entity.NewQuotaHolding("", tc.input.partnerID, tc.input.companyID, tc.input.quotas,
+			tc.input.nominalValue, tc.input.percentage, tc.input.effectiveDate, time.Time{})
+
+		require.NotEmpty(t, holding.ID)
+		require.NotZero(t, holding.CreatedAt)
+		require.True(t, tc.expectedCapital.Equal(holding.Capital()))
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			continue
+		}
+
+		require.Nil(t, err)
+	}
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Role string
+
+const (
+	RoleQuotaHolder         Role = "quota_holder"
+	RoleAdministrator       Role = "administrator"
+	RoleLegalRepresentative Role = "legal_representative"
+	RoleAttorneyInFact      Role = "attorney_in_fact"
+)
+
+var ErrDocumentFromAnotherPartner = errors.New("document belongs to another partner")
+
+// Powers granted by a role. Amounts are allowed up to their limit, inclusive, unless the power
+// is granted without limit, so a power with a zero limit allows nothing.
+type Powers struct {
+	SignContracts              bool
+	SignContractsUpTo          decimal.Decimal `validate:"gte=0"`
+	SignContractsUnlimited     bool
+	AuthorizePayments          bool
+	AuthorizePaymentsUpTo      decimal.Decimal `validate:"gte=0"`
+	AuthorizePaymentsUnlimited bool
+	OpenBankAccounts           bool
+}
+
+// RoleAssignment is a role held in a company from StartDate (inclusive) until EndDate (exclusive).
+// Every role but quota holder must be backed by a partner document, such as the articles of
+// association naming the administrator or a power of attorney.
+type RoleAssignment struct {
+	Role       Role      `validate:"required,oneof=quota_holder administrator legal_representative attorney_in_fact"`
+	Powers     Powers    `validate:""`
+	DocumentID string    `validate:"required_unless=Role quota_holder,omitempty,uuid"`
+	StartDate  time.Time `validate:"required"`
+	EndDate    time.Time `validate:"omitempty,gtfield=StartDate"`
+}
+
+func (r RoleAssignment) ActiveAt(date time.Time) bool {
+	if date.Before(r.StartDate) {
+		return false
+	}
+
+	return r.EndDate.IsZero() || date.Before(r.EndDate)
+}
+
+func (p Powers) CanSignContract(amount decimal.Decimal) bool {
+	return p.SignContracts && (p.SignContractsUnlimited || amount.LessThanOrEqual(p.SignContractsUpTo))
+}
+
+func (p Powers) CanAuthorizePayment(amount decimal.Decimal) bool {
+	return p.AuthorizePayments && (p.AuthorizePaymentsUnlimited || amount.LessThanOrEqual(p.AuthorizePaymentsUpTo))
+}
+
+// AssignRole grants a role in a company the partner is a member of. The document must be
+// one of the partner's own documents.
+func (p *Partner) AssignRole(companyID string, role Role, powers Powers, document *PartnerDocument,
+	startDate time.Time, endDate time.Time) error {
+
+	assignment := RoleAssignment{Role: role, Powers: powers, StartDate: startDate, EndDate: endDate}
+
+	if document != nil {
+		if document.PartnerID != p.ID {
+			return ErrDocumentFromAnotherPartner
+		}
+		assignment.DocumentID = document.ID
+	}
+
+	return p.updateMembership(companyID, func(m *Membership) error {
+		m.Roles = append(m.Roles, assignment)
+		return nil
+	})
+}
+
+type Signatory struct {
+	PartnerID  string
+	Role       Role
+	DocumentID string
+	Powers     Powers
+}
+
+// PaymentSignatories lists who can authorize a payment of amount for the company on date.
+func PaymentSignatories(partners []Partner, companyID string, amount decimal.Decimal, date time.Time) []Signatory {
+	var signatories []Signatory
+
+	for _, p := range partners {
+		m, ok := p.MembershipIn(companyID)
+		if !ok || !m.IsActive(date) {
+			continue
+		}
+
+		for _, r := range m.Roles {
+			if r.ActiveAt(date) && r.Powers.CanAuthorizePayment(amount) {
+				signatories = append(signatories, Signatory{
+					PartnerID:  p.ID,
+					Role:       r.Role,
+					DocumentID: r.DocumentID,
+					Powers:     r.Powers,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(signatories, func(i, j int) bool {
+		return signatories[i].PartnerID < signatories[j].PartnerID
+	})
+
+	return signatories
+}
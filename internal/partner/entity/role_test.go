@@ -0,0 +1,140 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/partner/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartner_AssignRole(t *testing.T) {
+	companyID := uuid.New().String()
+
+	partner, err := entity.NewPartner("", companyID, "John", "Doe", true, time.Time{})
+	require.Nil(t, err)
+
+	attorney, err := entity.NewDocument("", partner.ID, "Power of attorney", "", "pdf", time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	otherDoc, err := entity.NewDocument("", uuid.New().String(), "Power of attorney", "", "pdf", time.Time{}, time.Time{})
+	require.Nil(t, err)
+
+	start := time.Now()
+
+	err = partner.AssignRole(companyID, entity.RoleAttorneyInFact, entity.Powers{}, nil, start, time.Time{})
+	require.Equal(t, errors.New("invalid fields: Partner.Memberships[0].Roles[1].DocumentID: \"\""), err)
+
+	err = partner.AssignRole(companyID, entity.RoleAttorneyInFact, entity.Powers{}, &otherDoc, start, time.Time{})
+	require.Equal(t, entity.ErrDocumentFromAnotherPartner, err)
+
+	err = partner.AssignRole(uuid.New().String(), entity.RoleAttorneyInFact, entity.Powers{}, &attorney, start, time.Time{})
+	require.Equal(t, entity.ErrNotMember, err)
+
+	err = partner.AssignRole(companyID, entity.RoleAttorneyInFact, entity.Powers{SignContractsUpTo: decimal.NewFromInt(-1)},
+		&attorney, start, time.Time{})
+	require.Equal(t, errors.New("invalid fields: Partner.Memberships[0].Roles[1].Powers.SignContractsUpTo: \"-1\""), err)
+
+	err = partner.AssignRole(companyID, entity.RoleAttorneyInFact, entity.Powers{}, &attorney, start, start.Add(-time.Hour))
+	require.Error(t, err)
+
+	require.Len(t, partner.Memberships[0].Roles, 1)
+
+	err = partner.AssignRole(companyID, entity.RoleAttorneyInFact, entity.Powers{OpenBankAccounts: true}, &attorney,
+		start, time.Time{})
+	require.Nil(t, err)
+	require.Len(t, partner.Memberships[0].Roles, 2)
+	require.Equal(t, attorney.ID, partner.Memberships[0].Roles[1].DocumentID)
+}
+
+func TestPartner_PaymentSignatories(t *testing.T) {
+	type testCase struct {
+		test               string
+		companyID          string
+		amount             decimal.Decimal
+		date               time.Time
+		expectedSignatures []string
+	}
+
+	companyID := uuid.New().String()
+	today := time.Now()
+	lastYear := today.AddDate(-1, 0, 0)
+
+	newPartner := func(id string) entity.Partner {
+		p, err := entity.NewPartner(id, companyID, "Partner", "", true, lastYear)
+		require.Nil(t, err)
+		return p
+	}
+
+	assign := func(p *entity.Partner, role entity.Role, powers entity.Powers, start time.Time, end time.Time) {
+		doc, err := entity.NewDocument("", p.ID, "Role document", "", "pdf", time.Time{}, time.Time{})
+		require.Nil(t, err)
+		require.Nil(t, p.AssignRole(companyID, role, powers, &doc, start, end))
+	}
+
+	administrator := newPartner("00000000-0000-0000-0000-00000000000a")
+	assign(&administrator, entity.RoleAdministrator,
+		entity.Powers{AuthorizePayments: true, AuthorizePaymentsUnlimited: true}, lastYear, time.Time{})
+
+	attorney := newPartner("00000000-0000-0000-0000-00000000000b")
+	assign(&attorney, entity.RoleAttorneyInFact,
+		entity.Powers{AuthorizePayments: true, AuthorizePaymentsUpTo: decimal.NewFromInt(50000)}, lastYear, time.Time{})
+
+	formerAdministrator := newPartner("00000000-0000-0000-0000-00000000000c")
+	assign(&formerAdministrator, entity.RoleAdministrator,
+		entity.Powers{AuthorizePayments: true, AuthorizePaymentsUnlimited: true}, lastYear, lastYear.AddDate(0, 6, 0))
+
+	quotaHolder := newPartner("00000000-0000-0000-0000-00000000000d")
+
+	// Granted the power without a limit set, so it allows no payment.
+	representative := newPartner("00000000-0000-0000-0000-00000000000e")
+	assign(&representative, entity.RoleLegalRepresentative, entity.Powers{AuthorizePayments: true}, lastYear, time.Time{})
+
+	partners := []entity.Partner{quotaHolder, formerAdministrator, attorney, administrator, representative}
+
+	testsTable := []testCase{
+		{
+			test:               "Payment within the attorney limit",
+			companyID:          companyID,
+			amount:             decimal.NewFromInt(50000),
+			date:               today,
+			expectedSignatures: []string{administrator.ID, attorney.ID},
+		},
+		{
+			test:               "Payment above the attorney limit",
+			companyID:          companyID,
+			amount:             decimal.RequireFromString("50000.01"),
+			date:               today,
+			expectedSignatures: []string{administrator.ID},
+		},
+		{
+			test:               "Payment while the former administrator was in charge",
+			companyID:          companyID,
+			amount:             decimal.NewFromInt(100000),
+			date:               lastYear.AddDate(0, 1, 0),
+			expectedSignatures: []string{administrator.ID, formerAdministrator.ID},
+		},
+		{
+			test:      "Payment for another company",
+			companyID: uuid.New().String(),
+			amount:    decimal.NewFromInt(10),
+			date:      today,
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		signatories := entity.PaymentSignatories(partners, tc.companyID, tc.amount, tc.date)
+
+		var ids []string
+		for _, s := range signatories {
+			ids = append(ids, s.PartnerID)
+		}
+
+		require.Equal(t, tc.expectedSignatures, ids)
+	}
+}
@@ -0,0 +1,175 @@
+package calendar
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Calendar tells the workdays, for construction schedules as well as banking days: weekdays
+// that are not Brazilian national holidays nor one of the local holidays added to it. It is safe
+// for concurrent use.
+type Calendar struct {
+	local map[time.Time]string
+
+	mu       sync.Mutex
+	national map[int]map[time.Time]string
+}
+
+func NewCalendar(localHolidays ...Holiday) *Calendar {
+	c := &Calendar{local: make(map[time.Time]string), national: make(map[int]map[time.Time]string)}
+	for _, h := range localHolidays {
+		c.local[day(h.Date)] = h.Name
+	}
+	return c
+}
+
+func (c *Calendar) IsWorkday(d time.Time) bool {
+	if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		return false
+	}
+
+	if _, ok := c.local[day(d)]; ok {
+		return false
+	}
+
+	_, ok := c.nationalHolidays(d.Year())[day(d)]
+	return !ok
+}
+
+// nationalHolidays computes the national holidays of a year once, as workday counts check them
+// day by day.
+func (c *Calendar) nationalHolidays(year int) map[time.Time]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	holidays, ok := c.national[year]
+	if !ok {
+		holidays = nationalHolidays(year)
+		c.national[year] = holidays
+	}
+	return holidays
+}
+
+// NextWorkday returns d itself when it is a workday.
+func (c *Calendar) NextWorkday(d time.Time) time.Time {
+	d = day(d)
+	for !c.IsWorkday(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+// AddWorkdays moves n workdays from a workday. n may be negative.
+func (c *Calendar) AddWorkdays(d time.Time, n int) time.Time {
+	d = day(d)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for n > 0 {
+		d = d.AddDate(0, 0, step)
+		if c.IsWorkday(d) {
+			n--
+		}
+	}
+	return d
+}
+
+// Workdays counts the workdays from from, inclusive, to to, exclusive. It is negative when to
+// is before from.
+func (c *Calendar) Workdays(from time.Time, to time.Time) int {
+	from, to = day(from), day(to)
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+
+	n := 0
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		if c.IsWorkday(d) {
+			n++
+		}
+	}
+	return sign * n
+}
+
+// Holidays lists the national and local holidays of a year, in date order.
+func (c *Calendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for d, name := range c.nationalHolidays(year) {
+		holidays = append(holidays, Holiday{Date: d, Name: name})
+	}
+	for d, name := range c.local {
+		if d.Year() == year {
+			holidays = append(holidays, Holiday{Date: d, Name: name})
+		}
+	}
+
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// nationalHolidays includes Carnival, which is not a legal holiday but stops construction sites and banks.
+func nationalHolidays(year int) map[time.Time]string {
+	date := func(m time.Month, d int) time.Time { return time.Date(year, m, d, 0, 0, 0, 0, time.UTC) }
+	easter := Easter(year)
+
+	holidays := map[time.Time]string{
+		date(time.January, 1):     "Confraternização Universal",
+		easter.AddDate(0, 0, -48): "Carnaval",
+		easter.AddDate(0, 0, -47): "Carnaval",
+		easter.AddDate(0, 0, -2):  "Sexta-feira Santa",
+		date(time.April, 21):      "Tiradentes",
+		date(time.May, 1):         "Dia do Trabalho",
+		easter.AddDate(0, 0, 60):  "Corpus Christi",
+		date(time.September, 7):   "Independência do Brasil",
+		date(time.October, 12):    "Nossa Senhora Aparecida",
+		date(time.November, 2):    "Finados",
+		date(time.November, 15):   "Proclamação da República",
+		date(time.December, 25):   "Natal",
+	}
+
+	if year >= 2024 {
+		holidays[date(time.November, 20)] = "Dia Nacional de Zumbi e da Consciência Negra"
+	}
+
+	return holidays
+}
+
+// Easter computes the Easter Sunday of the Gregorian calendar (anonymous Gregorian algorithm).
+func Easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	dayOfMonth := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), dayOfMonth, 0, 0, 0, 0, time.UTC)
+}
+
+// AddMonths moves a date by whole months, keeping its day of the month or the last day of
+// shorter months, so installments due on the 31st fall on February 28th. months may be negative.
+func AddMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, months, 0)
+	last := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(t.Day(), last), 0, 0, 0, 0, t.Location())
+}
+
+// day drops the time of day. Dates are compared as calendar days in UTC.
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
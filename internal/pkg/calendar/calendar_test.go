@@ -0,0 +1,74 @@
+package calendar_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/calendar"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendar_IsWorkday(t *testing.T) {
+	cal := calendar.NewCalendar(calendar.Holiday{Date: date(2023, time.January, 25), Name: "Aniversário de São Paulo"})
+
+	type testCase struct {
+		test     string
+		date     time.Time
+		expected bool
+	}
+
+	testCases := []testCase{
+		{test: "Should work on a weekday", date: date(2023, time.April, 3), expected: true},
+		{test: "Should not work on Saturdays", date: date(2023, time.April, 1), expected: false},
+		{test: "Should not work on Sundays", date: date(2023, time.April, 2), expected: false},
+		{test: "Should not work on Good Friday", date: date(2023, time.April, 7), expected: false},
+		{test: "Should not work on Carnival", date: date(2024, time.February, 13), expected: false},
+		{test: "Should not work on Corpus Christi", date: date(2023, time.June, 8), expected: false},
+		{test: "Should not work on fixed national holidays", date: date(2023, time.September, 7), expected: false},
+		{test: "Should not work on local holidays", date: date(2023, time.January, 25), expected: false},
+		{test: "Should work on Black Consciousness Day before it became national", date: date(2023, time.November, 20), expected: true},
+		{test: "Should not work on Black Consciousness Day from 2024", date: date(2024, time.November, 20), expected: false},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		require.Equal(t, tc.expected, cal.IsWorkday(tc.date))
+	}
+
+	require.Equal(t, date(2024, time.March, 31), calendar.Easter(2024))
+	require.Equal(t, date(2023, time.April, 9), calendar.Easter(2023))
+
+	require.Equal(t, date(2023, time.April, 10), cal.AddWorkdays(date(2023, time.April, 6), 1))
+	require.Equal(t, date(2023, time.April, 6), cal.AddWorkdays(date(2023, time.April, 10), -1))
+	require.Equal(t, 4, cal.Workdays(date(2023, time.April, 3), date(2023, time.April, 10)))
+	require.Equal(t, -4, cal.Workdays(date(2023, time.April, 10), date(2023, time.April, 3)))
+	require.Equal(t, date(2023, time.April, 10), cal.NextWorkday(date(2023, time.April, 7)))
+}
+
+func TestCalendar_AddMonths(t *testing.T) {
+	type testCase struct {
+		test     string
+		date     time.Time
+		months   int
+		expected time.Time
+	}
+
+	testCases := []testCase{
+		{test: "Should keep the day of the month", date: date(2023, time.January, 10), months: 2, expected: date(2023, time.March, 10)},
+		{test: "Should clamp to the end of February", date: date(2023, time.January, 31), months: 1, expected: date(2023, time.February, 28)},
+		{test: "Should clamp to the end of February in leap years", date: date(2024, time.January, 31), months: 1, expected: date(2024, time.February, 29)},
+		{test: "Should clamp to the end of 30-day months", date: date(2023, time.January, 31), months: 3, expected: date(2023, time.April, 30)},
+		{test: "Should cross the year", date: date(2023, time.December, 31), months: 2, expected: date(2024, time.February, 29)},
+		{test: "Should move backwards", date: date(2023, time.March, 31), months: -1, expected: date(2023, time.February, 28)},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		require.Equal(t, tc.expected, calendar.AddMonths(tc.date, tc.months))
+	}
+}
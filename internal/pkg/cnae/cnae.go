@@ -0,0 +1,68 @@
+package cnae
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+// Subclasses of the IBGE CNAE 2.3 table used by the companies we manage.
+// Each line is "<7 digit code>;<description>".
+//
+//go:embed cnae.csv
+var table string
+
+type Activity struct {
+	Code        string
+	Description string
+}
+
+var activities = parseTable(table)
+
+func parseTable(t string) map[string]Activity {
+	m := make(map[string]Activity)
+
+	scanner := bufio.NewScanner(strings.NewReader(t))
+	for scanner.Scan() {
+		code, description, found := strings.Cut(scanner.Text(), ";")
+		if !found {
+			continue
+		}
+
+		code = Normalize(code)
+		m[code] = Activity{Code: Format(code), Description: strings.TrimSpace(description)}
+	}
+
+	return m
+}
+
+// Normalize strips the punctuation of a CNAE code, so "4120-4/00" becomes "4120400".
+func Normalize(code string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, code)
+}
+
+// Format returns the code in the official "0000-0/00" notation.
+func Format(code string) string {
+	code = Normalize(code)
+	if len(code) != 7 {
+		return code
+	}
+
+	return code[:4] + "-" + code[4:5] + "/" + code[5:]
+}
+
+func Lookup(code string) (Activity, bool) {
+	a, ok := activities[Normalize(code)]
+	return a, ok
+}
+
+func IsValid(code string) bool {
+	_, ok := Lookup(code)
+	return ok
+}
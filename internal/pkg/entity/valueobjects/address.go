@@ -0,0 +1,11 @@
+package valueobjects
+
+type Address struct {
+	Street     string `validate:"required,min=3"`
+	Number     string `validate:"required"`
+	Complement string `validate:""`
+	District   string `validate:"required"`
+	City       string `validate:"required,min=2"`
+	State      string `validate:"required,oneof=AC AL AP AM BA CE DF ES GO MA MT MS MG PA PB PR PE PI RJ RN RS RO RR SC SP SE TO"`
+	PostalCode string `validate:"required,postcode_iso3166_alpha2=BR"`
+}
@@ -0,0 +1,83 @@
+package valueobjects
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBankAccountNotFound  = errors.New("bank account not found")
+	ErrNoDefaultBankAccount = errors.New("no default bank account registered")
+)
+
+type BankAccountType string
+
+const (
+	CheckingAccount BankAccountType = "checking"
+	SavingsAccount  BankAccountType = "savings"
+	PaymentAccount  BankAccountType = "payment"
+)
+
+type BankAccount struct {
+	BankCode    string          `validate:"required,len=3,numeric"`
+	Branch      string          `validate:"required,max=4,numeric"`
+	Number      string          `validate:"required,max=12,numeric"`
+	CheckDigit  string          `validate:"required,len=1,alphanum"`
+	Type        BankAccountType `validate:"required,oneof=checking savings payment"`
+	HolderName  string          `validate:"required,min=2"`
+	HolderTaxID string          `validate:"required,taxid"`
+}
+
+// RegisteredBankAccount is an account registered to pay its holder. One of the accounts of a
+// holder is the default, used when no account is chosen.
+type RegisteredBankAccount struct {
+	ID      string      `validate:"required,uuid"`
+	Account BankAccount `validate:"required"`
+	Default bool        `validate:"-"`
+}
+
+// AddBankAccount returns a copy of accounts with account registered. The first account, or one
+// added as default, becomes the default.
+func AddBankAccount(accounts []RegisteredBankAccount, account BankAccount,
+	isDefault bool) ([]RegisteredBankAccount, RegisteredBankAccount) {
+
+	registered := RegisteredBankAccount{
+		ID:      uuid.New().String(),
+		Account: account,
+		Default: isDefault || len(accounts) == 0,
+	}
+
+	updated := append([]RegisteredBankAccount(nil), accounts...)
+	if registered.Default {
+		for i := range updated {
+			updated[i].Default = false
+		}
+	}
+
+	return append(updated, registered), registered
+}
+
+// SetDefaultBankAccount returns a copy of accounts with the account of id as the default.
+func SetDefaultBankAccount(accounts []RegisteredBankAccount, id string) ([]RegisteredBankAccount, error) {
+	found := false
+	updated := append([]RegisteredBankAccount(nil), accounts...)
+	for i := range updated {
+		updated[i].Default = updated[i].ID == id
+		found = found || updated[i].Default
+	}
+
+	if !found {
+		return nil, ErrBankAccountNotFound
+	}
+	return updated, nil
+}
+
+func DefaultBankAccount(accounts []RegisteredBankAccount) (RegisteredBankAccount, error) {
+	for _, ba := range accounts {
+		if ba.Default {
+			return ba, nil
+		}
+	}
+	return RegisteredBankAccount{}, ErrNoDefaultBankAccount
+}
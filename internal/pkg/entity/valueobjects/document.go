@@ -1,6 +1,18 @@
 package valueobjects
 
+import (
+	"crypto/md5"
+	"encoding/hex"
+)
+
 type Document struct {
 	FilePath  string `validate:"required,filepath"`
 	Extension string `validate:"required,lowercase,min=2"`
 }
+
+// DocumentEncryptedName is the name a document file is stored under, which does not disclose
+// its title.
+func DocumentEncryptedName(docID string, extension string) string {
+	hash := md5.Sum([]byte(docID))
+	return hex.EncodeToString(hash[:]) + "." + extension
+}
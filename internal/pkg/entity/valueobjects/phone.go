@@ -0,0 +1,27 @@
+package valueobjects
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizePhone converts a Brazilian phone number such as "(11) 98765-4321" to E.164.
+func NormalizePhone(number string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, number)
+
+	if strings.HasPrefix(number, "+") {
+		return "+" + digits
+	}
+
+	digits = strings.TrimLeft(digits, "0")
+	if len(digits) <= 11 {
+		digits = "55" + digits
+	}
+
+	return "+" + digits
+}
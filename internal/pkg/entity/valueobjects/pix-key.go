@@ -0,0 +1,83 @@
+package valueobjects
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/taxid"
+)
+
+type PixKeyType string
+
+const (
+	PixCPF   PixKeyType = "cpf"
+	PixCNPJ  PixKeyType = "cnpj"
+	PixEmail PixKeyType = "email"
+	PixPhone PixKeyType = "phone"
+	PixEVP   PixKeyType = "evp"
+)
+
+var ErrDuplicatedPixKey = errors.New("PIX key already registered")
+
+var (
+	pixPhoneRegex = regexp.MustCompile(`^\+55[1-9][1-9]\d{8,9}$`)
+	pixEVPRegex   = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+)
+
+// PixKey is validated by the "pixkey" tag according to its type, following the formats of the
+// Central Bank DICT: digits only CPF and CNPJ, lower case email up to 77 characters, E.164 phone
+// and random keys (EVP) as lower case UUID v4.
+type PixKey struct {
+	Type PixKeyType `validate:"required,oneof=cpf cnpj email phone evp"`
+	Key  string     `validate:"required,pixkey"`
+}
+
+func NewPixKey(keyType PixKeyType, key string) PixKey {
+	key = strings.TrimSpace(key)
+
+	switch keyType {
+	case PixCPF, PixCNPJ:
+		key = taxid.Normalize(key)
+	case PixEmail, PixEVP:
+		key = strings.ToLower(key)
+	case PixPhone:
+		key = "+" + strings.TrimLeft(strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, key), "0")
+	}
+
+	return PixKey{Type: keyType, Key: key}
+}
+
+func IsValidPixKey(keyType PixKeyType, key string) bool {
+	switch keyType {
+	case PixCPF:
+		return len(key) == 11 && taxid.IsCPF(key)
+	case PixCNPJ:
+		return len(key) == 14 && taxid.IsCNPJ(key)
+	case PixEmail:
+		addr, err := mail.ParseAddress(key)
+		return err == nil && addr.Address == key && len(key) <= 77 && key == strings.ToLower(key)
+	case PixPhone:
+		return pixPhoneRegex.MatchString(key)
+	case PixEVP:
+		return pixEVPRegex.MatchString(key)
+	default:
+		return false
+	}
+}
+
+// AddPixKey returns a copy of keys with key registered.
+func AddPixKey(keys []PixKey, key PixKey) ([]PixKey, error) {
+	for _, k := range keys {
+		if k == key {
+			return nil, ErrDuplicatedPixKey
+		}
+	}
+	return append(append([]PixKey(nil), keys...), key), nil
+}
@@ -0,0 +1,10 @@
+package events
+
+import "time"
+
+// Event is a fact raised by an aggregate. Aggregates keep their pending events until
+// the caller pulls them to dispatch after persisting the aggregate.
+type Event interface {
+	Name() string
+	OccurredAt() time.Time
+}
@@ -0,0 +1,133 @@
+package screening
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type ListKind string
+
+const (
+	KindSanctions ListKind = "sanctions"
+	KindPEP       ListKind = "pep"
+)
+
+var (
+	ErrNameColumnNotFound = errors.New("list has no name column")
+	ErrEmptyList          = errors.New("list has no entries")
+)
+
+// Header names, compared after NormalizeName, used to find the columns of the lists we import,
+// such as the CGU PEP list and the CEIS/CNEP sanctions registries.
+var (
+	nameColumns = []string{
+		"NOME", "NAME", "NOME PEP", "NOME DO SANCIONADO", "NOME INFORMADO PELO ORGAO SANCIONADOR",
+		"RAZAO SOCIAL CADASTRO RECEITA", "FULL NAME",
+	}
+	taxIDColumns = []string{
+		"CPF", "CNPJ", "CPF OU CNPJ", "CPF OU CNPJ DO SANCIONADO", "TAX ID", "DOCUMENTO",
+	}
+)
+
+type Entry struct {
+	Name  string
+	TaxID string
+	// Details keeps the whole imported row, as evidence of what was matched.
+	Details map[string]string
+}
+
+type List struct {
+	Source     string
+	Kind       ListKind
+	ImportedAt time.Time
+	Entries    []Entry
+}
+
+// ImportCSV reads a list published as CSV. The delimiter (comma or semicolon) and the name and
+// tax ID columns are detected from the header row.
+func ImportCSV(r io.Reader, source string, kind ListKind, importedAt time.Time) (List, error) {
+	buffered := bufio.NewReader(r)
+
+	firstLine, err := buffered.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return List{}, err
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = detectDelimiter(string(firstLine))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return List{}, fmt.Errorf("reading %s header: %w", source, err)
+	}
+
+	nameIdx := findColumn(header, nameColumns)
+	if nameIdx < 0 {
+		return List{}, fmt.Errorf("%w: %s", ErrNameColumnNotFound, source)
+	}
+	taxIDIdx := findColumn(header, taxIDColumns)
+
+	list := List{Source: source, Kind: kind, ImportedAt: importedAt}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return List{}, fmt.Errorf("reading %s: %w", source, err)
+		}
+
+		if nameIdx >= len(record) || strings.TrimSpace(record[nameIdx]) == "" {
+			continue
+		}
+
+		entry := Entry{Name: strings.TrimSpace(record[nameIdx]), Details: make(map[string]string)}
+		if taxIDIdx >= 0 && taxIDIdx < len(record) {
+			entry.TaxID = strings.TrimSpace(record[taxIDIdx])
+		}
+
+		for i, column := range header {
+			if i < len(record) {
+				entry.Details[strings.TrimSpace(column)] = strings.TrimSpace(record[i])
+			}
+		}
+
+		list.Entries = append(list.Entries, entry)
+	}
+
+	if len(list.Entries) == 0 {
+		return List{}, fmt.Errorf("%w: %s", ErrEmptyList, source)
+	}
+
+	return list, nil
+}
+
+func detectDelimiter(firstLine string) rune {
+	if i := strings.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		return ';'
+	}
+	return ','
+}
+
+func findColumn(header []string, candidates []string) int {
+	for _, candidate := range candidates {
+		for i, column := range header {
+			if NormalizeName(column) == candidate {
+				return i
+			}
+		}
+	}
+	return -1
+}
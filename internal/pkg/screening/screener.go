@@ -0,0 +1,125 @@
+package screening
+
+import (
+	"sort"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/taxid"
+)
+
+const DefaultThreshold = 0.92
+
+type MatchedBy string
+
+const (
+	MatchedByTaxID MatchedBy = "tax_id"
+	MatchedByName  MatchedBy = "name"
+)
+
+type Match struct {
+	Source    string
+	Kind      ListKind
+	Entry     Entry
+	Score     float64
+	MatchedBy MatchedBy
+}
+
+type ListReference struct {
+	Source     string
+	Kind       ListKind
+	ImportedAt time.Time
+	Entries    int
+}
+
+// Result is the evidence of a screening: who was screened, against which lists and what was found.
+type Result struct {
+	Name       string
+	TaxID      string
+	Threshold  float64
+	Lists      []ListReference
+	Matches    []Match
+	ScreenedAt time.Time
+}
+
+func (r Result) Clear() bool {
+	return len(r.Matches) == 0
+}
+
+type Screener struct {
+	threshold float64
+	lists     []List
+}
+
+func NewScreener(threshold float64, lists ...List) *Screener {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	return &Screener{threshold: threshold, lists: lists}
+}
+
+// Screen looks for the name and tax ID in every list. A tax ID is a match even when the list
+// masks some digits, as the CGU PEP list does with "***.982.247-**".
+func (s *Screener) Screen(name string, taxID string, at time.Time) Result {
+	result := Result{Name: name, TaxID: taxID, Threshold: s.threshold, ScreenedAt: at}
+
+	for _, list := range s.lists {
+		result.Lists = append(result.Lists, ListReference{
+			Source:     list.Source,
+			Kind:       list.Kind,
+			ImportedAt: list.ImportedAt,
+			Entries:    len(list.Entries),
+		})
+
+		for _, entry := range list.Entries {
+			match := Match{Source: list.Source, Kind: list.Kind, Entry: entry}
+
+			if taxID != "" && entry.TaxID != "" && sameTaxID(taxID, entry.TaxID) {
+				match.Score = 1
+				match.MatchedBy = MatchedByTaxID
+				result.Matches = append(result.Matches, match)
+				continue
+			}
+
+			if score := NameSimilarity(name, entry.Name); score >= s.threshold {
+				match.Score = score
+				match.MatchedBy = MatchedByName
+				result.Matches = append(result.Matches, match)
+			}
+		}
+	}
+
+	sort.SliceStable(result.Matches, func(i, j int) bool {
+		return result.Matches[i].Score > result.Matches[j].Score
+	})
+
+	return result
+}
+
+func sameTaxID(taxID string, listed string) bool {
+	digits := taxid.Normalize(taxID)
+
+	var pattern []rune
+	for _, r := range listed {
+		if r == '*' || (r >= '0' && r <= '9') {
+			pattern = append(pattern, r)
+		}
+	}
+
+	if len(pattern) != len(digits) {
+		return false
+	}
+
+	visible := 0
+	for i, r := range pattern {
+		if r == '*' {
+			continue
+		}
+		if byte(r) != digits[i] {
+			return false
+		}
+		visible++
+	}
+
+	return visible >= len(digits)/2
+}
@@ -0,0 +1,98 @@
+package screening_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/screening"
+	"github.com/stretchr/testify/require"
+)
+
+const pepCSV = `"CPF";"Nome_PEP";"Sigla_Função";"Descrição_Função"
+"***.982.247-**";"JOSÉ DA SILVA SAURO";"DEP";"DEPUTADO FEDERAL"
+"***.111.222-**";"MARIA APARECIDA DOS SANTOS";"SEC";"SECRETÁRIO MUNICIPAL"
+`
+
+const sanctionsCSV = `CPF OU CNPJ DO SANCIONADO,NOME DO SANCIONADO,CATEGORIA DA SANÇÃO
+11222333000181,CONSTRUTORA EXEMPLO LTDA,Inidoneidade
+`
+
+func TestScreener_Screen(t *testing.T) {
+	type testCase struct {
+		test            string
+		name            string
+		taxID           string
+		expectedMatches []screening.MatchedBy
+		expectedSource  string
+	}
+
+	importedAt := time.Now()
+
+	pep, err := screening.ImportCSV(strings.NewReader(pepCSV), "CGU PEP", screening.KindPEP, importedAt)
+	require.Nil(t, err)
+	require.Len(t, pep.Entries, 2)
+	require.Equal(t, "DEPUTADO FEDERAL", pep.Entries[0].Details["Descrição_Função"])
+
+	sanctions, err := screening.ImportCSV(strings.NewReader(sanctionsCSV), "CEIS", screening.KindSanctions, importedAt)
+	require.Nil(t, err)
+	require.Len(t, sanctions.Entries, 1)
+
+	_, err = screening.ImportCSV(strings.NewReader("a,b\n1,2\n"), "Unknown", screening.KindSanctions, importedAt)
+	require.ErrorIs(t, err, screening.ErrNameColumnNotFound)
+
+	screener := screening.NewScreener(0, pep, sanctions)
+
+	testsTable := []testCase{
+		{
+			test:            "Masked CPF match",
+			name:            "Someone Else",
+			taxID:           "529.982.247-25",
+			expectedMatches: []screening.MatchedBy{screening.MatchedByTaxID},
+			expectedSource:  "CGU PEP",
+		},
+		{
+			test:            "Name without accents and in another order",
+			name:            "Silva Sauro, Jose da",
+			expectedMatches: []screening.MatchedBy{screening.MatchedByName},
+			expectedSource:  "CGU PEP",
+		},
+		{
+			test:            "Name with a typo",
+			name:            "Maria Aparecida dos Santoss",
+			expectedMatches: []screening.MatchedBy{screening.MatchedByName},
+			expectedSource:  "CGU PEP",
+		},
+		{
+			test:            "Company CNPJ match",
+			name:            "Exemplo Engenharia",
+			taxID:           "11.222.333/0001-81",
+			expectedMatches: []screening.MatchedBy{screening.MatchedByTaxID},
+			expectedSource:  "CEIS",
+		},
+		{
+			test:  "Clear partner",
+			name:  "John Doe",
+			taxID: "111.444.777-35",
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		result := screener.Screen(tc.name, tc.taxID, importedAt)
+
+		require.Len(t, result.Lists, 2)
+		require.Equal(t, len(tc.expectedMatches) == 0, result.Clear())
+
+		var matchedBy []screening.MatchedBy
+		for _, m := range result.Matches {
+			matchedBy = append(matchedBy, m.MatchedBy)
+		}
+		require.Equal(t, tc.expectedMatches, matchedBy)
+
+		if len(tc.expectedMatches) > 0 {
+			require.Equal(t, tc.expectedSource, result.Matches[0].Source)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package taxid
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalize strips the punctuation of a CPF or CNPJ, so "012.345.678-90" becomes "01234567890".
+func Normalize(id string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, id)
+}
+
+func IsCPF(id string) bool {
+	digits := Normalize(id)
+	if len(digits) != 11 || repeated(digits) {
+		return false
+	}
+
+	return checkDigit(digits[:9], weights(10, 9)) == digits[9] &&
+		checkDigit(digits[:10], weights(11, 10)) == digits[10]
+}
+
+func IsCNPJ(id string) bool {
+	digits := Normalize(id)
+	if len(digits) != 14 || repeated(digits) {
+		return false
+	}
+
+	first := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	second := append([]int{6}, first...)
+
+	return checkDigit(digits[:12], first) == digits[12] && checkDigit(digits[:13], second) == digits[13]
+}
+
+// IsValid accepts either a CPF or a CNPJ.
+func IsValid(id string) bool {
+	return IsCPF(id) || IsCNPJ(id)
+}
+
+// Equal compares two tax IDs ignoring punctuation.
+func Equal(a string, b string) bool {
+	return Normalize(a) == Normalize(b)
+}
+
+func weights(from int, n int) []int {
+	w := make([]int, n)
+	for i := range w {
+		w[i] = from - i
+	}
+	return w
+}
+
+func checkDigit(digits string, weights []int) byte {
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
+
+func repeated(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
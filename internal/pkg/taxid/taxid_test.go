@@ -0,0 +1,35 @@
+package taxid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/taxid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxID_IsValid(t *testing.T) {
+	type testCase struct {
+		test         string
+		id           string
+		expectedCPF  bool
+		expectedCNPJ bool
+	}
+
+	testsTable := []testCase{
+		{test: "Formatted CPF", id: "529.982.247-25", expectedCPF: true},
+		{test: "Unformatted CPF", id: "52998224725", expectedCPF: true},
+		{test: "CPF with wrong check digit", id: "529.982.247-24"},
+		{test: "CPF with repeated digits", id: "111.111.111-11"},
+		{test: "Formatted CNPJ", id: "11.222.333/0001-81", expectedCNPJ: true},
+		{test: "CNPJ with wrong check digit", id: "11.222.333/0001-80"},
+		{test: "Empty tax ID", id: ""},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		require.Equal(t, tc.expectedCPF, taxid.IsCPF(tc.id))
+		require.Equal(t, tc.expectedCNPJ, taxid.IsCNPJ(tc.id))
+		require.Equal(t, tc.expectedCPF || tc.expectedCNPJ, taxid.IsValid(tc.id))
+	}
+}
@@ -2,19 +2,36 @@ package validator
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/cnae"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/taxid"
 	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
 )
 
+// Brazilian numbers in E.164: country code, two digit area code and an 8 digit landline
+// or a 9 digit mobile number.
+var brazilianPhoneRegex = regexp.MustCompile(`^\+55[1-9][1-9](9\d{8}|[2-5]\d{7})$`)
+
 type CustomValidate struct {
 	validate *validator.Validate
 }
 
 func NewCustomValidate() *CustomValidate {
-	return &CustomValidate{
-		validator.New(validator.WithRequiredStructEnabled()),
-	}
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterValidation("cnae", validateCNAE)
+	v.RegisterValidation("cpf", validateCPF)
+	v.RegisterValidation("cnpj", validateCNPJ)
+	v.RegisterValidation("taxid", validateTaxID)
+	v.RegisterValidation("brphone", validateBrazilianPhone)
+	v.RegisterValidation("pixkey", validatePixKey)
+	v.RegisterCustomTypeFunc(decimalValue, decimal.Decimal{})
+
+	return &CustomValidate{v}
 }
 
 func (cv *CustomValidate) Validate(s interface{}) error {
@@ -23,10 +40,42 @@ func (cv *CustomValidate) Validate(s interface{}) error {
 		var invalidFields []string
 
 		for _, e := range err.(validator.ValidationErrors) {
-			invalidFields = append(invalidFields, fmt.Sprintf("%s: \"%s\"", e.Namespace(), e.Value()))
+			invalidFields = append(invalidFields, fmt.Sprintf("%s: \"%v\"", e.Namespace(), e.Value()))
 		}
 
 		return fmt.Errorf("invalid fields: %s", strings.Join(invalidFields, ", "))
 	}
 	return nil
 }
+
+func validateCNAE(fl validator.FieldLevel) bool {
+	return cnae.IsValid(fl.Field().String())
+}
+
+func validateCPF(fl validator.FieldLevel) bool {
+	return taxid.IsCPF(fl.Field().String())
+}
+
+func validateCNPJ(fl validator.FieldLevel) bool {
+	return taxid.IsCNPJ(fl.Field().String())
+}
+
+func validateTaxID(fl validator.FieldLevel) bool {
+	return taxid.IsValid(fl.Field().String())
+}
+
+func validateBrazilianPhone(fl validator.FieldLevel) bool {
+	return brazilianPhoneRegex.MatchString(fl.Field().String())
+}
+
+func validatePixKey(fl validator.FieldLevel) bool {
+	key, ok := fl.Parent().Interface().(valueobjects.PixKey)
+	return ok && valueobjects.IsValidPixKey(key.Type, key.Key)
+}
+
+// decimalValue lets numeric tags such as gt=0 or lte=100 be used on decimal fields.
+func decimalValue(field reflect.Value) interface{} {
+	d := field.Interface().(decimal.Decimal)
+	f, _ := d.Float64()
+	return f
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/taxid"
+)
+
+var (
+	ErrHolderTaxIDMismatch  = errors.New("account holder tax ID does not match the supplier tax ID")
+	ErrBankAccountNotFound  = valueobjects.ErrBankAccountNotFound
+	ErrDuplicatedPixKey     = valueobjects.ErrDuplicatedPixKey
+	ErrNoDefaultBankAccount = valueobjects.ErrNoDefaultBankAccount
+)
+
+type SupplierBankAccount = valueobjects.RegisteredBankAccount
+
+// AddBankAccount registers an account held by the supplier. The first account, or one added
+// as default, becomes the account used for payments.
+func (s *Supplier) AddBankAccount(account valueobjects.BankAccount, isDefault bool) (SupplierBankAccount, error) {
+	if !taxid.Equal(s.TaxID, account.HolderTaxID) {
+		return SupplierBankAccount{}, ErrHolderTaxIDMismatch
+	}
+
+	updated := *s
+	var ba SupplierBankAccount
+	updated.BankAccounts, ba = valueobjects.AddBankAccount(s.BankAccounts, account, isDefault)
+
+	if err := s.update(updated); err != nil {
+		return SupplierBankAccount{}, err
+	}
+	return ba, nil
+}
+
+func (s *Supplier) SetDefaultBankAccount(id string) error {
+	accounts, err := valueobjects.SetDefaultBankAccount(s.BankAccounts, id)
+	if err != nil {
+		return err
+	}
+
+	updated := *s
+	updated.BankAccounts = accounts
+
+	return s.update(updated)
+}
+
+func (s Supplier) DefaultBankAccount() (SupplierBankAccount, error) {
+	return valueobjects.DefaultBankAccount(s.BankAccounts)
+}
+
+// AddPixKey registers a PIX key of the supplier. CPF and CNPJ keys must be the supplier's own tax ID.
+func (s *Supplier) AddPixKey(keyType valueobjects.PixKeyType, key string) (valueobjects.PixKey, error) {
+	pix := valueobjects.NewPixKey(keyType, key)
+
+	if (keyType == valueobjects.PixCPF || keyType == valueobjects.PixCNPJ) && !taxid.Equal(s.TaxID, pix.Key) {
+		return valueobjects.PixKey{}, ErrHolderTaxIDMismatch
+	}
+
+	keys, err := valueobjects.AddPixKey(s.PixKeys, pix)
+	if err != nil {
+		return valueobjects.PixKey{}, err
+	}
+
+	updated := *s
+	updated.PixKeys = keys
+
+	if err := s.update(updated); err != nil {
+		return valueobjects.PixKey{}, err
+	}
+	return pix, nil
+}
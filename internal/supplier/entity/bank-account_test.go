@@ -0,0 +1,70 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupplier_AddBankAccount(t *testing.T) {
+	account := valueobjects.BankAccount{
+		BankCode:    "237",
+		Branch:      "1234",
+		Number:      "987654",
+		CheckDigit:  "0",
+		Type:        valueobjects.CheckingAccount,
+		HolderName:  "Aço Forte Ltda",
+		HolderTaxID: "11222333000181",
+	}
+
+	s, err := entity.NewSupplier("", "Aço Forte Ltda", "", "11.222.333/0001-81",
+		[]entity.TradeCategory{entity.TradeSteel}, time.Time{})
+	require.Nil(t, err)
+
+	_, err = s.DefaultBankAccount()
+	require.Equal(t, entity.ErrNoDefaultBankAccount, err)
+
+	other := account
+	other.HolderTaxID = "529.982.247-25"
+	_, err = s.AddBankAccount(other, true)
+	require.Equal(t, entity.ErrHolderTaxIDMismatch, err)
+
+	invalid := account
+	invalid.Branch = "12a"
+	_, err = s.AddBankAccount(invalid, true)
+	require.Equal(t, errors.New("invalid fields: Supplier.BankAccounts[0].Account.Branch: \"12a\""), err)
+
+	first, err := s.AddBankAccount(account, false)
+	require.Nil(t, err)
+	require.True(t, first.Default)
+
+	second, err := s.AddBankAccount(account, true)
+	require.Nil(t, err)
+
+	def, err := s.DefaultBankAccount()
+	require.Nil(t, err)
+	require.Equal(t, second.ID, def.ID)
+
+	require.Equal(t, entity.ErrBankAccountNotFound, s.SetDefaultBankAccount("missing"))
+	require.Nil(t, s.SetDefaultBankAccount(first.ID))
+	def, _ = s.DefaultBankAccount()
+	require.Equal(t, first.ID, def.ID)
+
+	_, err = s.AddPixKey(valueobjects.PixCPF, "529.982.247-25")
+	require.Equal(t, entity.ErrHolderTaxIDMismatch, err)
+
+	pix, err := s.AddPixKey(valueobjects.PixCNPJ, "11.222.333/0001-81")
+	require.Nil(t, err)
+	require.Equal(t, "11222333000181", pix.Key)
+
+	_, err = s.AddPixKey(valueobjects.PixCNPJ, "11222333000181")
+	require.Equal(t, entity.ErrDuplicatedPixKey, err)
+
+	_, err = s.AddPixKey(valueobjects.PixEmail, "financeiro@acoforte.com.br")
+	require.Nil(t, err)
+	require.Len(t, s.PixKeys, 2)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rating is the evaluation of a supplier after a delivery, from 1 to 5. ConstructionID is empty
+// for evaluations not tied to a construction.
+type Rating struct {
+	ConstructionID string    `validate:"omitempty,uuid"`
+	Score          int       `validate:"gte=1,lte=5"`
+	Comment        string    `validate:"-"`
+	RatedBy        string    `validate:"required,min=3"`
+	RatedAt        time.Time `validate:"required"`
+}
+
+func (s *Supplier) Rate(constructionID string, score int, comment string, ratedBy string, ratedAt time.Time) error {
+	if ratedAt.IsZero() {
+		ratedAt = time.Now()
+	}
+
+	rating := Rating{
+		ConstructionID: constructionID,
+		Score:          score,
+		Comment:        strings.TrimSpace(comment),
+		RatedBy:        strings.TrimSpace(ratedBy),
+		RatedAt:        ratedAt,
+	}
+
+	updated := *s
+	updated.Ratings = append(append([]Rating(nil), s.Ratings...), rating)
+
+	return s.update(updated)
+}
+
+// AverageRating is the mean score of the supplier, rounded to one decimal place. It is zero
+// while the supplier has not been rated.
+func (s Supplier) AverageRating() decimal.Decimal {
+	if len(s.Ratings) == 0 {
+		return decimal.Zero
+	}
+
+	total := 0
+	for _, r := range s.Ratings {
+		total += r.Score
+	}
+	return decimal.NewFromInt(int64(total)).Div(decimal.NewFromInt(int64(len(s.Ratings)))).Round(1)
+}
@@ -0,0 +1,30 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupplier_Rate(t *testing.T) {
+	s, err := entity.NewSupplier("", "Pinturas Boa Vista Ltda", "", "11.222.333/0001-81",
+		[]entity.TradeCategory{entity.TradePainting}, time.Time{})
+	require.Nil(t, err)
+	require.True(t, decimal.Zero.Equal(s.AverageRating()))
+
+	err = s.Rate("", 6, "", "Eng. Carlos", time.Time{})
+	require.Equal(t, errors.New("invalid fields: Supplier.Ratings[0].Score: \"6\""), err)
+	require.Empty(t, s.Ratings)
+
+	constructionID := uuid.New().String()
+	require.Nil(t, s.Rate(constructionID, 5, "On time", "Eng. Carlos", time.Time{}))
+	require.Nil(t, s.Rate(constructionID, 4, "", "Eng. Carlos", time.Time{}))
+	require.Nil(t, s.Rate("", 4, "Rework on the facade", "Eng. Marta", time.Time{}))
+
+	require.True(t, decimal.RequireFromString("4.3").Equal(s.AverageRating()), s.AverageRating().String())
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"path"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+)
+
+// SupplierDocument holds the certificates (certidões) required to hire a supplier. Most of them
+// expire, so ExpiresAt is set when the certificate has a validity.
+type SupplierDocument struct {
+	ID          string                `validate:"required,uuid"`
+	SupplierID  string                `validate:"required,uuid"`
+	Title       string                `validate:"required,min=3"`
+	File        valueobjects.Document `validate:"required"`
+	ExpiresAt   time.Time             `validate:"omitempty,gtfield=CreatedAt"`
+	LastUpdated time.Time             `validate:"required,gtefield=CreatedAt"`
+	CreatedAt   time.Time             `validate:"required,ltefield=LastUpdated"`
+}
+
+func NewDocument(id string, supplierID string, title string, filePath string, fileExtension string,
+	expiresAt time.Time, lastUpdated time.Time, createdAt time.Time) (SupplierDocument, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if filePath == "" {
+		filePath = path.Join(SupplierRootPath(supplierID), valueobjects.DocumentEncryptedName(id, fileExtension))
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	if lastUpdated.IsZero() {
+		lastUpdated = time.Now()
+	}
+
+	docFile := valueobjects.Document{FilePath: filePath, Extension: fileExtension}
+
+	document := SupplierDocument{
+		ID:          id,
+		SupplierID:  supplierID,
+		Title:       title,
+		File:        docFile,
+		ExpiresAt:   expiresAt,
+		LastUpdated: lastUpdated,
+		CreatedAt:   createdAt,
+	}
+
+	return document, validateSupplierDoc(document)
+}
+
+// IsValidAt reports whether the document has not expired on date.
+func (sd SupplierDocument) IsValidAt(date time.Time) bool {
+	return sd.ExpiresAt.IsZero() || date.Before(sd.ExpiresAt)
+}
+
+func validateSupplierDoc(sd SupplierDocument) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(sd)
+	return err
+}
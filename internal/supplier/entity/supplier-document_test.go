@@ -0,0 +1,72 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupplierDocument_NewDocument(t *testing.T) {
+	type testCase struct {
+		test          string
+		supplierID    string
+		title         string
+		extension     string
+		expiresAt     time.Time
+		expectedError error
+	}
+
+	supplierID := uuid.New().String()
+	createdAt := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	expired := createdAt.AddDate(0, 0, -1)
+
+	testsTable := []testCase{
+		{
+			test:          "Empty SupplierID, Title and file extension error validation",
+			expectedError: errors.New("invalid fields: SupplierDocument.SupplierID: \"\", SupplierDocument.Title: \"\", SupplierDocument.File.Extension: \"\""),
+		},
+		{
+			test:          "Expiration before creation error validation",
+			supplierID:    supplierID,
+			title:         "Certidão Negativa de Débitos Trabalhistas",
+			extension:     "pdf",
+			expiresAt:     expired,
+			expectedError: fmt.Errorf("invalid fields: SupplierDocument.ExpiresAt: \"%s\"", expired),
+		},
+		{
+			test:       "Valid certificate with expiration",
+			supplierID: supplierID,
+			title:      "Certidão Negativa de Débitos Trabalhistas",
+			extension:  "pdf",
+			expiresAt:  createdAt.AddDate(0, 6, 0),
+		},
+		{
+			test:       "Valid document without expiration",
+			supplierID: supplierID,
+			title:      "Contrato social",
+			extension:  "pdf",
+		},
+	}
+
+	for _, tc := range testsTable {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+		doc, err := entity.NewDocument("", tc.supplierID, tc.title, "", tc.extension, tc.expiresAt, createdAt, createdAt)
+
+		require.NotEmpty(t, doc.ID)
+		require.NotEmpty(t, doc.File.FilePath)
+
+		if tc.expectedError != nil {
+			require.Equal(t, tc.expectedError, err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.True(t, doc.IsValidAt(createdAt.AddDate(0, 5, 0)))
+		require.Equal(t, tc.expiresAt.IsZero(), doc.IsValidAt(createdAt.AddDate(0, 7, 0)))
+	}
+}
@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/validator"
+	"github.com/google/uuid"
+)
+
+type TradeCategory string
+
+const (
+	TradeConcrete        TradeCategory = "concrete"
+	TradeSteel           TradeCategory = "steel"
+	TradeElectrical      TradeCategory = "electrical"
+	TradePlumbing        TradeCategory = "plumbing"
+	TradeMasonry         TradeCategory = "masonry"
+	TradeCarpentry       TradeCategory = "carpentry"
+	TradePainting        TradeCategory = "painting"
+	TradeEarthwork       TradeCategory = "earthwork"
+	TradeEquipmentRental TradeCategory = "equipment_rental"
+	TradeBuildingSupply  TradeCategory = "building_supply"
+	TradeEngineering     TradeCategory = "engineering"
+)
+
+var (
+	ErrSupplierBlocked    = errors.New("supplier is blocked")
+	ErrSupplierNotBlocked = errors.New("supplier is not blocked")
+)
+
+type Contact struct {
+	Name  string `validate:"required,min=2"`
+	Role  string `validate:"-"`
+	Email string `validate:"required_without=Phone,omitempty,email"`
+	Phone string `validate:"required_without=Email,omitempty,e164,brphone"`
+}
+
+// Supplier is a counterparty of expenditures and contracts: material suppliers as well as
+// subcontractors. A blocked supplier keeps its history but cannot be hired.
+type Supplier struct {
+	ID           string                `validate:"required,uuid"`
+	LegalName    string                `validate:"required,min=2"`
+	TradeName    string                `validate:"-"`
+	TaxID        string                `validate:"required,taxid"`
+	Categories   []TradeCategory       `validate:"required,min=1,dive,oneof=concrete steel electrical plumbing masonry carpentry painting earthwork equipment_rental building_supply engineering"`
+	Address      *valueobjects.Address `validate:"omitempty"`
+	Contacts     []Contact             `validate:"dive"`
+	BankAccounts []SupplierBankAccount `validate:"dive"`
+	PixKeys      []valueobjects.PixKey `validate:"dive"`
+	Ratings      []Rating              `validate:"dive"`
+	Blocked      bool                  `validate:"-"`
+	BlockReason  string                `validate:"required_if=Blocked true,omitempty,min=3"`
+	BlockedAt    time.Time             `validate:"required_if=Blocked true"`
+	CreatedAt    time.Time             `validate:"required"`
+}
+
+func NewSupplier(id string, legalName string, tradeName string, taxID string, categories []TradeCategory,
+	createdAt time.Time) (Supplier, error) {
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	s := Supplier{
+		ID:         id,
+		LegalName:  legalName,
+		TradeName:  tradeName,
+		TaxID:      taxID,
+		Categories: categories,
+		CreatedAt:  createdAt,
+	}
+
+	return s, validateSupplier(s)
+}
+
+func (s Supplier) Supplies(category TradeCategory) bool {
+	for _, c := range s.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Supplier) SetAddress(address valueobjects.Address) error {
+	updated := *s
+	updated.Address = &address
+
+	return s.update(updated)
+}
+
+func (s *Supplier) AddContact(name string, role string, email string, phone string) error {
+	contact := Contact{
+		Name:  strings.TrimSpace(name),
+		Role:  strings.TrimSpace(role),
+		Email: strings.TrimSpace(email),
+		Phone: phone,
+	}
+	if phone != "" {
+		contact.Phone = valueobjects.NormalizePhone(phone)
+	}
+
+	updated := *s
+	updated.Contacts = append(append([]Contact(nil), s.Contacts...), contact)
+
+	return s.update(updated)
+}
+
+func (s *Supplier) Block(reason string, at time.Time) error {
+	if s.Blocked {
+		return ErrSupplierBlocked
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	updated := *s
+	updated.Blocked = true
+	updated.BlockReason = strings.TrimSpace(reason)
+	updated.BlockedAt = at
+
+	return s.update(updated)
+}
+
+func (s *Supplier) Unblock() error {
+	if !s.Blocked {
+		return ErrSupplierNotBlocked
+	}
+
+	updated := *s
+	updated.Blocked = false
+	updated.BlockReason = ""
+	updated.BlockedAt = time.Time{}
+
+	return s.update(updated)
+}
+
+func (s *Supplier) update(updated Supplier) error {
+	if err := validateSupplier(updated); err != nil {
+		return err
+	}
+
+	*s = updated
+	return nil
+}
+
+func validateSupplier(s Supplier) error {
+	cv := validator.NewCustomValidate()
+	err := cv.Validate(s)
+	return err
+}
+
+func SupplierRootPath(supplierID string) string {
+	hash := md5.Sum([]byte(supplierID))
+	return "Supplier-" + hex.EncodeToString(hash[:])
+}
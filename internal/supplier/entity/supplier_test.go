@@ -0,0 +1,105 @@
+package entity_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LHS-Real-Estate/cim-core/internal/pkg/entity/valueobjects"
+	"github.com/LHS-Real-Estate/cim-core/internal/supplier/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupplier_NewSupplier(t *testing.T) {
+	type testCase struct {
+		test        string
+		legalName   string
+		taxID       string
+		categories  []entity.TradeCategory
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:        "Should require a valid tax ID and at least one category",
+			legalName:   "Aço Forte Ltda",
+			taxID:       "11.222.333/0001-00",
+			expectedErr: errors.New("invalid fields: Supplier.TaxID: \"11.222.333/0001-00\", Supplier.Categories: \"[]\""),
+		},
+		{
+			test:        "Should reject unknown categories",
+			legalName:   "Aço Forte Ltda",
+			taxID:       "11.222.333/0001-81",
+			categories:  []entity.TradeCategory{entity.TradeSteel, "gardening"},
+			expectedErr: errors.New("invalid fields: Supplier.Categories[1]: \"gardening\""),
+		},
+		{
+			test:       "Should accept individual subcontractors identified by CPF",
+			legalName:  "José da Silva",
+			taxID:      "529.982.247-25",
+			categories: []entity.TradeCategory{entity.TradeMasonry},
+		},
+		{
+			test:       "Should create a supplier",
+			legalName:  "Aço Forte Ltda",
+			taxID:      "11.222.333/0001-81",
+			categories: []entity.TradeCategory{entity.TradeSteel, entity.TradeBuildingSupply},
+		},
+	}
+
+	for _, tc := range testCases {
+		fmt.Printf("Test case: %s\n\n", tc.test)
+
+		s, err := entity.NewSupplier("", tc.legalName, "", tc.taxID, tc.categories, time.Time{})
+		if tc.expectedErr != nil {
+			require.Equal(t, tc.expectedErr, err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.NotEmpty(t, s.ID)
+		require.NotZero(t, s.CreatedAt)
+		require.True(t, s.Supplies(tc.categories[0]))
+		require.False(t, s.Supplies(entity.TradePainting))
+	}
+}
+
+func TestSupplier_Contacts(t *testing.T) {
+	s, err := entity.NewSupplier("", "Elétrica Central Ltda", "Elétrica Central", "11.222.333/0001-81",
+		[]entity.TradeCategory{entity.TradeElectrical}, time.Time{})
+	require.Nil(t, err)
+
+	err = s.AddContact("Ana", "Comercial", "", "")
+	require.Equal(t, errors.New("invalid fields: Supplier.Contacts[0].Email: \"\", Supplier.Contacts[0].Phone: \"\""), err)
+	require.Empty(t, s.Contacts)
+
+	require.Nil(t, s.AddContact("Ana Lima", "Comercial", "ana@eletricacentral.com.br", "(11) 98765-4321"))
+	require.Equal(t, "+5511987654321", s.Contacts[0].Phone)
+
+	err = s.SetAddress(valueobjects.Address{Street: "Rua Augusta", Number: "100", District: "Consolação", City: "São Paulo", State: "XX", PostalCode: "01304-000"})
+	require.Equal(t, errors.New("invalid fields: Supplier.Address.State: \"XX\""), err)
+	require.Nil(t, s.Address)
+}
+
+func TestSupplier_Block(t *testing.T) {
+	s, err := entity.NewSupplier("", "Concreteira Paulista Ltda", "", "11.222.333/0001-81",
+		[]entity.TradeCategory{entity.TradeConcrete}, time.Time{})
+	require.Nil(t, err)
+
+	require.Equal(t, entity.ErrSupplierNotBlocked, s.Unblock())
+
+	err = s.Block("", time.Time{})
+	require.Equal(t, errors.New("invalid fields: Supplier.BlockReason: \"\""), err)
+	require.False(t, s.Blocked)
+
+	blockedAt := time.Date(2023, time.May, 2, 0, 0, 0, 0, time.UTC)
+	require.Nil(t, s.Block("Concrete delivered below the specified fck", blockedAt))
+	require.True(t, s.Blocked)
+	require.Equal(t, blockedAt, s.BlockedAt)
+	require.Equal(t, entity.ErrSupplierBlocked, s.Block("Again", time.Time{}))
+
+	require.Nil(t, s.Unblock())
+	require.False(t, s.Blocked)
+	require.Empty(t, s.BlockReason)
+}